@@ -1,64 +1,135 @@
 package cmd
 
 import (
-	"fmt"
 	"github.com/spf13/cobra"
-	"github.com/wakflo/go-sdk/client"
 	"github.com/wakflo/wakflo-cli/internal/templates"
 )
 
-func newAddCmd(floClient *client.Client) *cobra.Command {
+func newAddCmd(s *session) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "add",
 		Short: "Add resources to Wakflo",
 		Long:  "Use this command to add actions, triggers, or flows in Wakflo.",
 	}
 
-	for _, operationCmd := range newOperationsCmd(floClient) {
+	for _, operationCmd := range newOperationsCmd(s) {
 		cmd.AddCommand(operationCmd)
 	}
 
 	return cmd
 }
 
-func newOperationsCmd(floClient *client.Client) []*cobra.Command {
+type addOptions struct {
+	name        string
+	description string
+	typ         string
+	dir         string
+	force       bool
+
+	inputOptions
+}
+
+func defaultAddOptions() *addOptions {
+	return &addOptions{dir: "."}
+}
+
+func newOperationsCmd(s *session) []*cobra.Command {
 	// Subcommand for adding an action
+	actionOptions := defaultAddOptions()
 	addActionCmd := &cobra.Command{
 		Use:   "action",
 		Short: "Add a new action to the integration",
 		Long:  "Use this command to add a new action to the current integration project.",
+		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return templates.HandleAddResource("action", cmd, floClient)
+			return actionOptions.run(cmd, s, "action")
 		},
 	}
 
+	registerAddFlags(addActionCmd, actionOptions)
+
 	// Subcommand for adding a trigger
+	triggerOptions := defaultAddOptions()
 	addTriggerCmd := &cobra.Command{
 		Use:   "trigger",
 		Short: "Add a new trigger to the integration",
 		Long:  "Use this command to add a new trigger to the current integration project.",
+		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return templates.HandleAddResource("trigger", cmd, floClient)
+			return triggerOptions.run(cmd, s, "trigger")
 		},
 	}
 
+	registerAddFlags(addTriggerCmd, triggerOptions)
+
+	// Subcommand for adding a flow
+	flowOptions := &addFlowOptions{dir: "."}
 	addFlowCmd := &cobra.Command{
 		Use:   "flow",
 		Short: "Add a new flow",
-		Long:  "Use this command to add a new flow to Wakflo.",
-		Run: func(cmd *cobra.Command, args []string) {
-			// Add logic to add a flow
-			fmt.Println("Flow added successfully!")
+		Long: "Use this command to add a new flow to the current integration project. A flow is started by a trigger and runs actions in order, " +
+			"referenced as 'integration/name' or just 'name' for resources of the current project.",
+		Example: "  wakflo add flow --name \"Notify team\" --trigger new_message --step slack/send_message --input send_message.channel=general",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return templates.HandleAddFlow(&templates.AddFlowInput{
+				Name:        flowOptions.name,
+				Description: flowOptions.description,
+				Trigger:     flowOptions.trigger,
+				Steps:       flowOptions.steps,
+				Inputs:      flowOptions.inputs,
+				Interactive: flowOptions.canPrompt(),
+			}, s.generatingProject(cmd, flowOptions.dir, flowOptions.force, &flowOptions.inputOptions))
 		},
 	}
 
+	addFlowCmd.Flags().StringVarP(&flowOptions.name, "name", "n", "", "Name of the flow (prompted for when omitted)")
+	addFlowCmd.Flags().StringVarP(&flowOptions.description, "description", "d", "", "Description of the flow")
+	addFlowCmd.Flags().StringVar(&flowOptions.trigger, "trigger", "", "Trigger starting the flow, as 'integration/trigger' or 'trigger'")
+	addFlowCmd.Flags().StringArrayVar(&flowOptions.steps, "step", nil, "Action run by the flow, as 'integration/action' or 'action' (repeatable, in order)")
+	addFlowCmd.Flags().StringArrayVar(&flowOptions.inputs, "input", nil, "Input mapping as '<step id>.<key>=<value>', 'trigger' being the trigger's step id (repeatable)")
+	addFlowCmd.Flags().StringVar(&flowOptions.dir, "dir", flowOptions.dir, "Directory of the integration project")
+	addFlowCmd.Flags().BoolVarP(&flowOptions.force, "force", "f", flowOptions.force, "Overwrite the flow file if it already exists")
+	registerInputFlags(addFlowCmd, &flowOptions.inputOptions)
+
 	return []*cobra.Command{addActionCmd, addTriggerCmd, addFlowCmd}
 }
 
-func registerAddFlags(cmd *cobra.Command) {
-	cmd.Flags().StringP("name", "n", "", "Name of the resource (required)")
-	cmd.Flags().StringP("description", "d", "", "Description of the resource")
-	cmd.Flags().StringP("type", "t", "", "Type of the resource (e.g., sdkcore.ActionType or sdkcore.TriggerType)")
-	cmd.MarkFlagRequired("name")
-	cmd.MarkFlagRequired("type")
+type addFlowOptions struct {
+	name        string
+	description string
+	trigger     string
+	steps       []string
+	inputs      []string
+	dir         string
+	force       bool
+
+	inputOptions
+}
+
+func registerAddFlags(cmd *cobra.Command, o *addOptions) {
+	cmd.Flags().StringVarP(&o.name, "name", "n", o.name, "Name of the resource (prompted for when omitted)")
+	cmd.Flags().StringVarP(&o.description, "description", "d", o.description, "Description of the resource (generated when omitted)")
+	cmd.Flags().StringVarP(&o.typ, "type", "t", o.typ, "Type of the resource, e.g. 'polling' or 'sdkcore.TriggerTypePolling'")
+	cmd.Flags().StringVar(&o.dir, "dir", o.dir, "Directory of the integration project")
+	cmd.Flags().BoolVarP(&o.force, "force", "f", o.force, "Overwrite the resource files if they already exist")
+	registerInputFlags(cmd, &o.inputOptions)
+}
+
+func (o *addOptions) run(cmd *cobra.Command, s *session, kind string) error {
+	pack, err := s.Templates(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	p := s.generatingProject(cmd, o.dir, o.force, &o.inputOptions)
+	p.Pack = pack
+
+	return templates.HandleAddResource(cmd.Context(), kind, &templates.AddResourceInput{
+		Name:           o.name,
+		Description:    o.description,
+		Type:           o.typ,
+		Interactive:    o.canPrompt(),
+		AcceptDefaults: !o.promptDefaults(),
+	}, s.Generator(cmd.ErrOrStderr()), p)
 }
@@ -1,40 +1,260 @@
 package cmd
 
 import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/samber/lo"
 	"github.com/spf13/cobra"
-	"github.com/wakflo/go-sdk/client"
 	"github.com/wakflo/wakflo-cli/internal/auth"
 )
 
-func newAuthCmd(floClient *client.Client) *cobra.Command {
-	auth := auth.New()
-
+func newAuthCmd(s *session) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "auth",
 		Short: "Manage authentication for Wakflo",
-		Long:  "Use this command to log in or log out of Wakflo.",
+		Long:  "Use this command to log in or log out of Wakflo, inspect the current session and manage profiles.",
 	}
 
+	var (
+		noBrowser bool
+		workspace string
+	)
+
 	authLoginCmd := &cobra.Command{
 		Use:   "login",
 		Short: "Log in to Wakflo",
-		Long:  "Use this command to log in to Wakflo and authenticate your session.",
-		Run: func(cmd *cobra.Command, args []string) {
-			auth.Login(cmd)
+		Long:  "Use this command to log in to Wakflo and authenticate your session using the OAuth device flow. The profile logged into becomes the active profile.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			a, err := s.Auth()
+			if err != nil {
+				return err
+			}
+
+			profile := *s.profile
+			profile.APIURL = a.BaseURL
+
+			if workspace != "" {
+				profile.Workspace = workspace
+				a.Workspace = workspace
+			}
+
+			a.NoBrowser = noBrowser
+
+			if err := a.Login(cmd); err != nil {
+				return err
+			}
+
+			s.profiles.Set(&profile)
+			s.profiles.Active = profile.Name
+
+			if err := s.profiles.Save(); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Profile '%s' is now active.\n", profile.Name)
+
+			return nil
 		},
 	}
 
+	authLoginCmd.Flags().BoolVar(&noBrowser, "no-browser", false, "Do not open the verification URL in a browser")
+	authLoginCmd.Flags().StringVar(&workspace, "workspace", "", "Default workspace stored in the profile")
+
 	authLogoutCmd := &cobra.Command{
 		Use:   "logout",
 		Short: "Log out of Wakflo",
-		Long:  "Use this command to log out of Wakflo and end your session.",
-		Run: func(cmd *cobra.Command, args []string) {
-			auth.Logout(cmd)
+		Long:  "Use this command to log out of Wakflo and end the session of the active profile.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			a, err := s.Auth()
+			if err != nil {
+				return err
+			}
+
+			return a.Logout(cmd)
 		},
 	}
 
+	var whoamiOutput string
+
+	authWhoamiCmd := &cobra.Command{
+		Use:   "whoami",
+		Short: "Show the account you are logged in as",
+		Long:  "Use this command to display the account and workspace of the current session.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			a, err := s.Auth()
+			if err != nil {
+				return err
+			}
+
+			identity, err := a.WhoAMI(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			return printOutput(cmd.OutOrStdout(), whoamiOutput, identity, func(w io.Writer) {
+				fmt.Fprintf(w, "Account:   %s\n", formatAccount(&identity.Account))
+				fmt.Fprintf(w, "Workspace: %s\n", formatWorkspace(identity.Workspace))
+			})
+		},
+	}
+
+	registerOutputFlag(authWhoamiCmd, &whoamiOutput)
+
+	var statusOutput string
+
+	authStatusCmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the authentication status",
+		Long:  "Use this command to display whether you are logged in, who the session belongs to, when the token expires and which API is used.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			a, err := s.Auth()
+			if err != nil {
+				return err
+			}
+
+			status, err := a.Status(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			return printOutput(cmd.OutOrStdout(), statusOutput, status, func(w io.Writer) {
+				if !status.LoggedIn {
+					fmt.Fprintf(w, "Profile '%s' is not logged in to %s, run 'wakflo auth login'.\n", status.Profile, status.APIURL)
+					return
+				}
+
+				fmt.Fprintf(w, "Profile:      %s\n", status.Profile)
+				fmt.Fprintf(w, "Environment:  %s\n", status.Environment)
+				fmt.Fprintf(w, "API:          %s\n", status.APIURL)
+				fmt.Fprintf(w, "Token source: %s\n", status.TokenSource)
+				fmt.Fprintf(w, "Expires:      %s\n", formatExpiry(status.ExpiresAt))
+
+				if status.Error != "" {
+					fmt.Fprintf(w, "Account:      unavailable (%s)\n", status.Error)
+					return
+				}
+
+				fmt.Fprintf(w, "Account:      %s\n", formatAccount(status.Account))
+				fmt.Fprintf(w, "Workspace:    %s\n", formatWorkspace(status.Workspace))
+			})
+		},
+	}
+
+	registerOutputFlag(authStatusCmd, &statusOutput)
+
+	authSwitchCmd := &cobra.Command{
+		Use:   "switch <profile>",
+		Short: "Switch the active profile",
+		Long:  "Use this command to select the profile used by all commands that talk to the Wakflo API.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			profiles, err := s.Profiles()
+			if err != nil {
+				return err
+			}
+
+			if err := profiles.Use(args[0]); err != nil {
+				return err
+			}
+
+			if err := profiles.Save(); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Switched to profile '%s'.\n", args[0])
+
+			return nil
+		},
+	}
+
+	var listOutput string
+
+	authListCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List profiles",
+		Long:  "Use this command to list the configured profiles. The active profile is marked with '*'.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			active, err := s.Profile()
+			if err != nil {
+				return err
+			}
+
+			profiles := s.profiles.List()
+
+			return printOutput(cmd.OutOrStdout(), listOutput, profiles, func(w io.Writer) {
+				if len(profiles) == 0 {
+					fmt.Fprintln(w, "No profiles yet, run 'wakflo auth login --profile <name>' to create one.")
+					return
+				}
+
+				tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+				fmt.Fprintln(tw, "\tPROFILE\tAPI\tWORKSPACE")
+
+				for _, profile := range profiles {
+					marker := ""
+					if profile.Name == active.Name {
+						marker = "*"
+					}
+
+					fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", marker, profile.Name, lo.Ternary(profile.APIURL == "", "-", profile.APIURL), lo.Ternary(profile.Workspace == "", "-", profile.Workspace))
+				}
+
+				tw.Flush()
+			})
+		},
+	}
+
+	registerOutputFlag(authListCmd, &listOutput)
+
 	cmd.AddCommand(authLoginCmd)
 	cmd.AddCommand(authLogoutCmd)
+	cmd.AddCommand(authWhoamiCmd)
+	cmd.AddCommand(authStatusCmd)
+	cmd.AddCommand(authSwitchCmd)
+	cmd.AddCommand(authListCmd)
 
 	return cmd
 }
+
+func formatAccount(account *auth.Account) string {
+	switch {
+	case account == nil:
+		return "-"
+	case account.Email != "" && account.Name != "":
+		return fmt.Sprintf("%s <%s>", account.Name, account.Email)
+	case account.Email != "":
+		return account.Email
+	case account.Name != "":
+		return account.Name
+	default:
+		return account.ID
+	}
+}
+
+func formatWorkspace(workspace *auth.Workspace) string {
+	if workspace == nil {
+		return "-"
+	}
+
+	if workspace.Name != "" {
+		return workspace.Name
+	}
+
+	return workspace.ID
+}
+
+func formatExpiry(expiry *time.Time) string {
+	if expiry == nil {
+		return "never"
+	}
+
+	return fmt.Sprintf("%s (in %s)", expiry.Local().Format(time.RFC1123), time.Until(*expiry).Round(time.Minute))
+}
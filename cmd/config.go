@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/wakflo/wakflo-cli/internal/config"
+)
+
+func newConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage Wakflo CLI configuration",
+		Long: fmt.Sprintf("Use this command to read and change the CLI configuration. User-level settings live in %s inside the user config dir, "+
+			"project-level settings in a %s file which overrides them for the directory it is in and everything below.", config.FileName, config.ProjectFileName),
+	}
+
+	configGetCmd := &cobra.Command{
+		Use:   "get <key>",
+		Short: "Print a configuration value",
+		Long:  "Use this command to print the effective value of a configuration key, e.g. 'api.url' or 'defaults.authors'.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key, err := config.LookupKey(args[0])
+			if err != nil {
+				return err
+			}
+
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), key.Get(cfg))
+
+			return nil
+		},
+	}
+
+	var project bool
+
+	configSetCmd := &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Change a configuration value",
+		Long:  "Use this command to change a configuration value. Lists such as 'defaults.authors' are comma-separated and an empty value clears the key.",
+		Args:  cobra.ExactArgs(2), //nolint: gomnd
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key, err := config.LookupKey(args[0])
+			if err != nil {
+				return err
+			}
+
+			if project && key.UserOnly() {
+				return fmt.Errorf("'%s' is only read from the user-level config", key.Name)
+			}
+
+			path, err := configFilePath(project)
+			if err != nil {
+				return err
+			}
+
+			cfg, err := config.LoadFile(path)
+			if err != nil {
+				return err
+			}
+
+			key.Set(cfg, args[1])
+
+			if err := config.SaveFile(path, cfg); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Set '%s' in %s\n", key.Name, path)
+
+			return nil
+		},
+	}
+
+	configSetCmd.Flags().BoolVar(&project, "project", false, fmt.Sprintf("Write to the project-level %s instead of the user-level config", config.ProjectFileName))
+
+	var listOutput string
+
+	configListCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List configuration values",
+		Long:  "Use this command to list the effective configuration, user-level and project-level settings merged.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+
+			return printOutput(cmd.OutOrStdout(), listOutput, cfg, func(w io.Writer) {
+				tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+				for _, key := range config.Keys() {
+					fmt.Fprintf(tw, "%s\t%s\n", key.Name, key.Get(cfg))
+				}
+
+				tw.Flush()
+			})
+		},
+	}
+
+	registerOutputFlag(configListCmd, &listOutput)
+
+	cmd.AddCommand(configGetCmd)
+	cmd.AddCommand(configSetCmd)
+	cmd.AddCommand(configListCmd)
+
+	return cmd
+}
+
+// configFilePath returns the user-level config file, or with project set the
+// closest project-level one, creating it in the working directory if needed.
+func configFilePath(project bool) (string, error) {
+	if !project {
+		return config.UserFile()
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+
+	if path := config.ProjectFile(wd); path != "" {
+		return path, nil
+	}
+
+	return filepath.Join(wd, config.ProjectFileName), nil
+}
@@ -1,170 +1,240 @@
 package cmd
 
 import (
-	"context"
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/samber/lo"
 
 	"github.com/spf13/cobra"
-	"github.com/wakflo/go-sdk/client"
 	"github.com/wakflo/go-sdk/sdk"
 	"github.com/wakflo/go-sdk/validator"
+	"github.com/wakflo/wakflo-cli/internal/config"
 	"github.com/wakflo/wakflo-cli/internal/templates"
 )
 
 var val = validator.NewDefaultValidator()
 
-func newCreateCmd(floClient *client.Client) *cobra.Command {
+func newCreateCmd(s *session) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "create",
 		Short: "Create resources in Wakflo",
 		Long:  "Use this command to create resources such as integrations in Wakflo.",
 	}
 
-	cmd.AddCommand(newCreateIntegrationCmd(floClient))
+	cmd.AddCommand(newCreateIntegrationCmd(s))
 
 	return cmd
 }
 
-func newCreateIntegrationCmd(floClient *client.Client) *cobra.Command {
+type createIntegrationOptions struct {
+	name        string
+	description string
+	icon        string
+	categories  []string
+	authors     []string
+	version     string
+	dir         string
+	force       bool
+
+	inputOptions
+}
+
+func defaultCreateIntegrationOptions() *createIntegrationOptions {
+	return &createIntegrationOptions{dir: "."}
+}
+
+func newCreateIntegrationCmd(s *session) *cobra.Command {
+	o := defaultCreateIntegrationOptions()
+
 	cmd := &cobra.Command{
 		Use:     "integration",
 		Aliases: []string{"i", "int", "integ", "integrations"},
 		Short:   "Create a new integration",
-		Long:    "Use this command to create a new integration in Wakflo.",
-		Run: func(cmd *cobra.Command, args []string) {
-			// Step 1: Ask for the name of the integration
-			var name string
-			err := survey.AskOne(&survey.Input{
-				Message: "Enter Name of the integration (required):",
-			}, &name, survey.WithValidator(survey.Required))
-			if err != nil {
-				fmt.Printf("Name operation canceled %v+ \n", err)
-				return
-			}
+		Long: "Use this command to create a new integration in Wakflo. Values passed as flags are used as is, " +
+			"missing ones are prompted for when a terminal is attached and fall back to generated or configured defaults otherwise. " +
+			"With --yes, only the name is prompted for and defaults are accepted, and with --no-input nothing is.",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run(cmd, s)
+		},
+	}
 
-			// Step 2: Automatically generate description
-			descMessage := fmt.Sprintf("%s integration", name)
-			generateResponse, err := floClient.Rest.GenerateDescription(context.Background(), client.RestGenerateDescriptionRequest{
-				Prompt: descMessage,
-				Type:   "integration",
-			})
-			if err != nil {
-				fmt.Printf("Failed to generate description: %v\n", err)
-				return
-			}
+	cmd.Flags().StringVarP(&o.name, "name", "n", o.name, "Name of the integration")
+	cmd.Flags().StringVarP(&o.description, "description", "d", o.description, "Description of the integration (generated when omitted)")
+	cmd.Flags().StringVar(&o.icon, "icon", o.icon, "Icon of the integration (searched for when omitted)")
+	cmd.Flags().StringSliceVar(&o.categories, "categories", o.categories, "Comma-separated categories (defaults to the 'defaults.categories' config)")
+	cmd.Flags().StringSliceVar(&o.authors, "authors", o.authors, "Comma-separated authors (defaults to the 'defaults.authors' config)")
+	cmd.Flags().StringVar(&o.version, "version", o.version, "Version of the integration (defaults to the 'defaults.version' config)")
+	cmd.Flags().StringVar(&o.dir, "dir", o.dir, "Directory to create the integration folder in")
+	cmd.Flags().BoolVarP(&o.force, "force", "f", o.force, "Overwrite the files of the integration folder if it already exists")
+	registerInputFlags(cmd, &o.inputOptions)
+
+	return cmd
+}
+
+func (o *createIntegrationOptions) run(cmd *cobra.Command, s *session) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	gen := s.Generator(cmd.ErrOrStderr())
+	ctx := cmd.Context()
 
-			var description string
-			err = survey.AskOne(&survey.Input{
+	// Load the templates before asking anything
+	pack, err := s.Templates(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Step 1: Ask for the name of the integration
+	name := o.name
+	if name == "" {
+		if !o.canPrompt() {
+			return errors.New("--name is required when not running interactively")
+		}
+
+		if err := survey.AskOne(&survey.Input{
+			Message: "Enter Name of the integration (required):",
+		}, &name, survey.WithValidator(survey.Required)); err != nil {
+			return fmt.Errorf("name operation canceled: %w", err)
+		}
+	}
+
+	// Step 2: Automatically generate description
+	descMessage := fmt.Sprintf("%s integration", name)
+
+	description := o.description
+	if description == "" {
+		description, err = gen.Description(ctx, "integration", descMessage)
+		if err != nil {
+			return fmt.Errorf("failed to generate description: %w", err)
+		}
+
+		if o.promptDefaults() {
+			if err := survey.AskOne(&survey.Input{
 				Message: "Enter Description of the integration (edit or accept the default):",
-				Default: strings.Trim(generateResponse.Data, `"'`),
-			}, &description)
-			if err != nil {
-				fmt.Printf("Description operation canceled %v+ \n", err)
-				return
+				Default: description,
+			}, &description); err != nil {
+				return fmt.Errorf("description operation canceled: %w", err)
 			}
+		}
+	}
 
-			// Step 3: Fetch and choose an icon for the integration
-			iconResponse, err := floClient.Rest.SearchIcon(context.Background(), client.RestSearchIconRequest{
-				Name: name,
-			})
-			if err != nil {
-				fmt.Printf("Failed to fetch icons: %v\n", err)
-				return
+	// Step 3: Fetch and choose an icon for the integration
+	icon := o.icon
+	if icon == "" {
+		icons, err := gen.Icons(ctx, name)
+		if err != nil {
+			return fmt.Errorf("failed to fetch icons: %w", err)
+		}
+
+		switch {
+		case !o.promptDefaults():
+			icon = lo.FirstOr(icons, templates.PlaceholderIcon)
+		case len(icons) == 0:
+			if err := survey.AskOne(&survey.Input{
+				Message: "Enter an Icon for the integration:",
+			}, &icon); err != nil {
+				return fmt.Errorf("icon operation canceled: %w", err)
 			}
-
-			var icon string
-			if len(iconResponse.Icons) == 0 {
-				err = survey.AskOne(&survey.Input{
-					Message: "Enter an Icon for the integration:",
-				}, &icon)
-				if err != nil {
-					fmt.Printf("Description operation canceled %v+ \n", err)
-					return
-				}
-			} else {
-				err = survey.AskOne(&survey.Select{
-					Message: "Select an Icon for the integration:",
-					Options: iconResponse.Icons,
-				}, &icon)
-				if err != nil {
-					fmt.Printf("Icon operation canceled %v+ \n", err)
-					return
-				}
+		default:
+			if err := survey.AskOne(&survey.Select{
+				Message: "Select an Icon for the integration:",
+				Options: icons,
+			}, &icon); err != nil {
+				return fmt.Errorf("icon operation canceled: %w", err)
 			}
+		}
+	}
 
-			// Step 4: List categories and allow user to pick multiple
-			catResponse, err := floClient.Rest.ListCategories(context.Background(), client.RestListCategoriesRequest{})
+	// Step 4: List categories and allow user to pick multiple
+	categories := o.categories
+	if len(categories) == 0 {
+		categories = cfg.Defaults.CategoriesOrDefault()
+
+		if o.promptDefaults() {
+			options, err := gen.Categories(ctx)
 			if err != nil {
-				fmt.Printf("Failed to fetch categories: %v\n", err)
-				return
+				return fmt.Errorf("failed to fetch categories: %w", err)
 			}
 
-			var categories []string
-			err = survey.AskOne(&survey.MultiSelect{
+			if err := survey.AskOne(&survey.MultiSelect{
 				Message: "Select Categories for the integration:",
-				Options: catResponse.Keys,
-				Default: []string{"app"},
-			}, &categories)
-			if err != nil {
-				fmt.Printf("Categories operation canceled %v+ \n", err)
-				return
+				Options: options,
+				Default: lo.Intersect(options, categories),
+			}, &categories); err != nil {
+				return fmt.Errorf("categories operation canceled: %w", err)
 			}
+		}
+	}
+
+	// Step 5: Ask for authors
+	authors := o.authors
+	if len(authors) == 0 {
+		authors = cfg.Defaults.AuthorsOrDefault()
 
-			// Step 5: Ask for authors
+		if o.promptDefaults() {
 			var authorsInput string
-			err = survey.AskOne(&survey.Input{
+			if err := survey.AskOne(&survey.Input{
 				Message: "Enter Authors of the integration (comma-separated):",
-				Default: "Wakflo <integrations@wakflo.com>",
-			}, &authorsInput)
-			if err != nil {
-				fmt.Printf("Authors operation canceled %v+ \n", err)
-				return
+				Default: strings.Join(authors, ","),
+			}, &authorsInput); err != nil {
+				return fmt.Errorf("authors operation canceled: %w", err)
 			}
-			authors := strings.Split(authorsInput, ",")
 
-			// Step 6: Automatically generate documentation
-			docResponse, err := floClient.Rest.GenerateDocumentation(context.Background(), client.RestGenerateDocumentationRequest{
-				Prompt: descMessage,
-				Type:   "integration",
-			})
-			if err != nil {
-				fmt.Printf("Failed to generate documentation: %v\n", err)
-				return
-			}
+			authors = strings.Split(authorsInput, ",")
+		}
+	}
 
-			// Step 7: Create integration metadata
-			meta := &templates.CreateIntegrationProps{
-				IntegrationSchemaModel: sdk.IntegrationSchemaModel{
-					Name:        name,
-					Description: description,
-					Categories:  categories,
-					Icon:        icon,
-					Authors:     authors,
-					Version:     "0.0.1",
-				},
-				Docs: docResponse.Data,
-			}
+	authors = lo.Map(authors, func(author string, _ int) string {
+		return strings.TrimSpace(author)
+	})
 
-			// Step 8: Validate the data
-			err = val.Validate(meta)
-			if err != nil {
-				fmt.Printf("Invalid integration metadata: %v\n", err)
-				return
-			}
+	version := o.version
+	if version == "" {
+		version = cfg.Defaults.VersionOrDefault()
+	}
 
-			// Step 9: Create the integration folder
-			err = templates.CreateIntegrationFolder(meta)
-			if err != nil {
-				fmt.Printf("Failed to create integration: %v\n", err)
-				return
-			}
+	// Step 6: Automatically generate documentation
+	docs, err := gen.Documentation(ctx, "integration", descMessage)
+	if err != nil {
+		return fmt.Errorf("failed to generate documentation: %w", err)
+	}
 
-			fmt.Println("Integration created successfully!")
+	// Step 7: Create integration metadata
+	meta := &templates.CreateIntegrationProps{
+		IntegrationSchemaModel: sdk.IntegrationSchemaModel{
+			Name:        name,
+			Description: description,
+			Categories:  categories,
+			Icon:        icon,
+			Authors:     authors,
+			Version:     version,
 		},
+		Docs: docs,
 	}
 
-	return cmd
+	// Step 8: Validate the data
+	if err := val.Validate(meta); err != nil {
+		return fmt.Errorf("invalid integration metadata: %w", err)
+	}
+
+	// Step 9: Create the integration folder
+	p := s.generatingProject(cmd, o.dir, o.force, &o.inputOptions)
+	p.Pack = pack
+
+	if err := templates.CreateIntegrationFolder(meta, p); err != nil {
+		return fmt.Errorf("failed to create integration: %w", err)
+	}
+
+	if !s.dryRun {
+		fmt.Fprintln(cmd.OutOrStdout(), "Integration created successfully!")
+	}
+
+	return nil
 }
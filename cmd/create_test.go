@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateIntegrationNoInput(t *testing.T) {
+	testCases := []struct {
+		name  string
+		args  []string
+		files []string
+		err   string
+	}{
+		{
+			name:  "flags",
+			args:  []string{"--name", "Acme Chat", "--description", "Chat with Acme", "--icon", "mdi:chat", "--categories", "communication", "--authors", "Jane <jane@acme.test>", "--version", "1.2.0"},
+			files: []string{"README.md", "flo.toml", "lib.go"},
+		},
+		{
+			name: "missing name",
+			args: []string{"--icon", "mdi:chat"},
+			err:  "--name is required when not running interactively",
+		},
+		{
+			name: "name leaving the directory",
+			args: []string{"--name", "../Acme"},
+			err:  "invalid integration name '../Acme'",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			isolateConfig(t)
+			dir := t.TempDir()
+
+			cmd := newRootCmd("v1.0.0")
+			cmd.SetArgs(append([]string{"--offline", "create", "integration", "--no-input", "--dir", dir}, tc.args...))
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+
+			err := cmd.Execute()
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+
+				entries, err := os.ReadDir(dir)
+				require.NoError(t, err)
+				assert.Empty(t, entries)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			for _, file := range tc.files {
+				assert.FileExists(t, filepath.Join(dir, "acmechat", file))
+			}
+
+			flo, err := os.ReadFile(filepath.Join(dir, "acmechat", "flo.toml"))
+			require.NoError(t, err)
+			assert.Contains(t, string(flo), `name = "Acme Chat"`)
+			assert.Contains(t, string(flo), `Chat with Acme`)
+			assert.Contains(t, string(flo), `icon = "mdi:chat"`)
+			assert.Contains(t, string(flo), `categories = ["communication"]`)
+			assert.Contains(t, string(flo), `authors = ["Jane <jane@acme.test>"]`)
+			assert.Contains(t, string(flo), `version = "1.2.0"`)
+		})
+	}
+}
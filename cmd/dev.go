@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"slices"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"github.com/wakflo/wakflo-cli/internal/runner"
+)
+
+func newDevCmd(s *session) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dev",
+		Short: "Develop resources locally",
+		Long:  "Use this command to serve the resources of the current integration project while developing them.",
+	}
+
+	cmd.AddCommand(newDevWebhookCmd(s))
+
+	return cmd
+}
+
+type devWebhookOptions struct {
+	addr          string
+	redactHeaders []string
+	redactQuery   []string
+
+	runOptions
+}
+
+func newDevWebhookCmd(s *session) *cobra.Command {
+	o := &devWebhookOptions{addr: "127.0.0.1:8080", runOptions: *defaultRunOptions()}
+
+	cmd := &cobra.Command{
+		Use:   "webhook <trigger>",
+		Short: "Receive webhooks locally",
+		Long: "Use this command to start an HTTP server forwarding every request it receives to a trigger of the current integration project. " +
+			"The trigger is started, then executed with its input extended with the 'method', 'path', 'headers', 'query' and 'body' of each request, " +
+			"and its output is printed and sent back as the response. The trigger is stopped on Ctrl-C. " +
+			"With --record, each execution is saved as a fixture to replay with 'wakflo run trigger --replay', " +
+			"with the values of the Authorization, Cookie, signature and --redact-header headers " +
+			"and of the token, key, secret, signature and --redact-query query parameters replaced.",
+		Example: "  wakflo dev webhook new_message\n" +
+			"  wakflo dev webhook new_message --addr :9000 --record",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			input, auth, err := o.read(cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			r, err := runner.Start(ctx, o.dir, cmd.ErrOrStderr())
+			if err != nil {
+				return err
+			}
+			defer r.Close()
+
+			name, err := r.ResolveName(runner.KindTrigger, args[0])
+			if err != nil {
+				return err
+			}
+
+			l, err := net.Listen("tcp", o.addr)
+			if err != nil {
+				return fmt.Errorf("failed to listen on '%s': %w", o.addr, err)
+			}
+
+			fmt.Fprintf(cmd.ErrOrStderr(), "Forwarding requests sent to http://%s to trigger '%s', press Ctrl-C to stop.\n", l.Addr(), name)
+
+			return r.ServeWebhook(ctx, l, name, runner.TriggerOptions{Input: input, Auth: auth}, func(event runner.Event, req *runner.WebhookRequest) {
+				fmt.Fprintf(cmd.ErrOrStderr(), "%s %s\n", req.Method, req.Path)
+				printEvent(cmd.OutOrStdout(), cmd.ErrOrStderr(), event)
+
+				if !o.record {
+					return
+				}
+
+				// credentials and signatures are not saved with the request
+				redacted, err := req.Redact(slices.Concat(runner.RedactedHeaders, o.redactHeaders), slices.Concat(runner.RedactedQuery, o.redactQuery)).Input(input)
+				if err != nil {
+					fmt.Fprintf(cmd.ErrOrStderr(), "failed to record the fixture: %v\n", err)
+					return
+				}
+
+				event.Input = redacted
+				o.recordFixture(cmd.ErrOrStderr(), runner.KindTrigger, name, event)
+			})
+		},
+	}
+
+	registerRunFlags(cmd, &o.runOptions)
+	cmd.Flags().StringVar(&o.addr, "addr", o.addr, "Address to listen on")
+	cmd.Flags().StringArrayVar(&o.redactHeaders, "redact-header", o.redactHeaders, "Header to redact from recorded fixtures on top of the credential and signature ones (repeatable)")
+	cmd.Flags().StringArrayVar(&o.redactQuery, "redact-query", o.redactQuery, "Query parameter to redact from recorded fixtures on top of the credential and signature ones (repeatable)")
+
+	return cmd
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// inputOptions controls whether a command may prompt for missing values.
+type inputOptions struct {
+	yes     bool
+	noInput bool
+}
+
+func registerInputFlags(cmd *cobra.Command, o *inputOptions) {
+	cmd.Flags().BoolVarP(&o.yes, "yes", "y", o.yes, "Accept generated and configured defaults instead of prompting, still prompting for required values")
+	cmd.Flags().BoolVar(&o.noInput, "no-input", o.noInput, "Never prompt, fail when a required value is missing")
+}
+
+// canPrompt reports whether missing required values may be asked for
+// interactively.
+func (o *inputOptions) canPrompt() bool {
+	return !o.noInput && isTerminal(os.Stdin) && isTerminal(os.Stdout)
+}
+
+// promptDefaults reports whether values that have a default may be asked for
+// interactively, which --yes skips.
+func (o *inputOptions) promptDefaults() bool {
+	return !o.yes && o.canPrompt()
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
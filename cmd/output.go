@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"github.com/wakflo/wakflo-cli/internal/config"
+)
+
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+func registerOutputFlag(cmd *cobra.Command, output *string) {
+	cmd.Flags().StringVarP(output, "output", "o", "", "Output format, 'text' or 'json' (defaults to the 'output.format' config or 'text')")
+}
+
+// printOutput writes v as indented JSON when output is "json" and calls text otherwise.
+// An empty output falls back to the configured 'output.format'.
+func printOutput(w io.Writer, output string, v any, text func(w io.Writer)) error {
+	if output == "" {
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+
+		output = cfg.Output.Format
+	}
+
+	switch output {
+	case outputJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+
+		return enc.Encode(v)
+	case outputText, "":
+		text(w)
+
+		return nil
+	default:
+		return fmt.Errorf("unknown output format '%s', expected '%s' or '%s'", output, outputText, outputJSON)
+	}
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wakflo/wakflo-cli/internal/templates"
+)
+
+func newRemoveCmd(s *session) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "remove",
+		Aliases: []string{"rm"},
+		Short:   "Remove resources from an integration",
+		Long:    "Use this command to remove actions or triggers previously added to the current integration project.",
+	}
+
+	for _, kind := range []string{"action", "trigger"} {
+		cmd.AddCommand(newRemoveResourceCmd(s, kind))
+	}
+
+	return cmd
+}
+
+func newRemoveResourceCmd(s *session, kind string) *cobra.Command {
+	dir := "."
+	cmd := &cobra.Command{
+		Use:   kind + " <name>",
+		Short: fmt.Sprintf("Remove a %s from the integration", kind),
+		Long: fmt.Sprintf("Use this command to remove a %s from the current integration project. The files generated for it by the template pack are deleted, "+
+			"and its doc.go embed, lib.go registration and README row are removed. Nothing is changed if any of these edits fails.", kind),
+		Example:      fmt.Sprintf("  wakflo remove %s send_message", kind),
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// the files to edit are the ones the active pack generates
+			pack, err := s.Templates(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			p := s.Project(cmd, dir)
+			p.Pack = pack
+
+			changes, err := templates.HandleRemoveResource(kind, args[0], p)
+			if err != nil {
+				return err
+			}
+
+			if s.dryRun {
+				return nil
+			}
+
+			for _, change := range changes {
+				fmt.Fprintf(cmd.OutOrStdout(), "  %s\n", change)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "%s '%s' removed successfully.\n", kind, args[0])
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "dir", dir, "Directory of the integration project")
+
+	return cmd
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wakflo/wakflo-cli/internal/templates"
+)
+
+func newRenameCmd(s *session) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "rename",
+		Aliases: []string{"mv"},
+		Short:   "Rename resources of an integration",
+		Long:    "Use this command to rename actions or triggers of the current integration project.",
+	}
+
+	for _, kind := range []string{"action", "trigger"} {
+		cmd.AddCommand(newRenameResourceCmd(s, kind))
+	}
+
+	return cmd
+}
+
+func newRenameResourceCmd(s *session, kind string) *cobra.Command {
+	dir := "."
+	cmd := &cobra.Command{
+		Use:   kind + " <name> <new name>",
+		Short: fmt.Sprintf("Rename a %s of the integration", kind),
+		Long: fmt.Sprintf("Use this command to rename a %s of the current integration project. Its files, type, constructor and docs variable, "+
+			"its doc.go embed, lib.go registration and README row are renamed together. Nothing is changed if any of these edits fails.", kind),
+		Example:      fmt.Sprintf("  wakflo rename %s send_message \"Post Message\"", kind),
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// the files to edit are the ones the active pack generates
+			pack, err := s.Templates(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			p := s.Project(cmd, dir)
+			p.Pack = pack
+
+			changes, err := templates.HandleRenameResource(kind, args[0], args[1], p)
+			if err != nil {
+				return err
+			}
+
+			if s.dryRun {
+				return nil
+			}
+
+			for _, change := range changes {
+				fmt.Fprintf(cmd.OutOrStdout(), "  %s\n", change)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "%s '%s' renamed to '%s' successfully.\n", kind, args[0], args[1])
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "dir", dir, "Directory of the integration project")
+
+	return cmd
+}
@@ -2,13 +2,13 @@ package cmd
 
 import (
 	"fmt"
-	"github.com/wakflo/go-sdk/client"
-	"log"
 
 	"github.com/spf13/cobra"
 )
 
 func newRootCmd(version string) *cobra.Command {
+	s := &session{}
+
 	cmd := &cobra.Command{
 		Use:   "wakflo",
 		Short: "Wakflo is a CLI tool for managing integrations, actions, triggers, and flows.",
@@ -18,16 +18,20 @@ func newRootCmd(version string) *cobra.Command {
 		},
 	}
 
-	floClient, err := client.New(client.Local)
-	if err != nil {
-		log.Fatal(err)
-	}
+	registerSessionFlags(cmd, s)
 
 	cmd.AddCommand(newVersionCmd(version)) // version subcommand
 	//cmd.AddCommand(newExampleCmd())        // example subcommand
-	cmd.AddCommand(newAuthCmd(floClient))   // auth subcommand
-	cmd.AddCommand(newCreateCmd(floClient)) // create subcommand
-	cmd.AddCommand(newAddCmd(floClient))    // add subcommand
+	cmd.AddCommand(newAuthCmd(s))      // auth subcommand
+	cmd.AddCommand(newCreateCmd(s))    // create subcommand
+	cmd.AddCommand(newAddCmd(s))       // add subcommand
+	cmd.AddCommand(newRemoveCmd(s))    // remove subcommand
+	cmd.AddCommand(newRenameCmd(s))    // rename subcommand
+	cmd.AddCommand(newTemplatesCmd(s)) // templates subcommand
+	cmd.AddCommand(newRunCmd(s))       // run subcommand
+	cmd.AddCommand(newDevCmd(s))       // dev subcommand
+	cmd.AddCommand(newVerifyCmd(s))    // verify subcommand
+	cmd.AddCommand(newConfigCmd())     // config subcommand
 
 	return cmd
 }
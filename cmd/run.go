@@ -0,0 +1,337 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/wakflo/wakflo-cli/internal/runner"
+)
+
+func newRunCmd(s *session) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "run",
+		Short: "Run resources locally",
+		Long:  "Use this command to build the current integration project and run its actions and triggers on your machine.",
+	}
+
+	cmd.AddCommand(newRunActionCmd(s))
+	cmd.AddCommand(newRunTriggerCmd(s))
+
+	return cmd
+}
+
+type runOptions struct {
+	dir      string
+	input    string
+	auth     string
+	record   bool
+	replay   bool
+	fixtures string
+}
+
+func defaultRunOptions() *runOptions {
+	return &runOptions{dir: "."}
+}
+
+func registerRunFlags(cmd *cobra.Command, o *runOptions) {
+	cmd.Flags().StringVar(&o.dir, "dir", o.dir, "Directory of the integration project")
+	cmd.Flags().StringVarP(&o.input, "input", "i", o.input, "JSON file with the input of the resource, '-' to read it from stdin")
+	cmd.Flags().StringVar(&o.auth, "auth", o.auth, "JSON file with the auth context, e.g. {\"access_token\": \"...\"}, '-' to read it from stdin")
+	cmd.Flags().BoolVar(&o.record, "record", o.record, "Save the input and output of each execution as a fixture")
+	cmd.Flags().StringVar(&o.fixtures, "fixtures", o.fixtures, "Directory of the fixtures (defaults to 'testdata' in the project)")
+}
+
+func registerReplayFlag(cmd *cobra.Command, o *runOptions) {
+	cmd.Flags().BoolVar(&o.replay, "replay", o.replay, "Run the resource with the input of each of its fixtures and compare the outputs with the recorded ones")
+	cmd.MarkFlagsMutuallyExclusive("record", "replay")
+	cmd.MarkFlagsMutuallyExclusive("input", "replay")
+}
+
+func newRunActionCmd(s *session) *cobra.Command {
+	o := defaultRunOptions()
+
+	cmd := &cobra.Command{
+		Use:   "action <name>",
+		Short: "Run an action locally",
+		Long: "Use this command to build the current integration project and call the Perform method of one of its actions, " +
+			"referenced by its name or file name. The JSON it returns is printed, what the action prints goes to stderr. " +
+			"With --record, the input and output are saved as a fixture in 'testdata/actions/<action>', and --replay runs the action " +
+			"again with the input of every fixture, reporting the outputs that changed.",
+		Example: "  wakflo run action send_message --input input.json --auth auth.json\n" +
+			"  wakflo run action send_message --input input.json --record\n" +
+			"  wakflo run action send_message --replay",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			input, auth, err := o.read(cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			r, err := runner.Start(ctx, o.dir, cmd.ErrOrStderr())
+			if err != nil {
+				return err
+			}
+			defer r.Close()
+
+			// actions are not stopped gracefully, Ctrl-C aborts the one running
+			defer context.AfterFunc(ctx, func() { _ = r.Kill() })()
+
+			name, err := r.ResolveName(runner.KindAction, args[0])
+			if err != nil {
+				return err
+			}
+
+			if o.replay {
+				return o.replayFixtures(cmd, r, runner.KindAction, name, auth)
+			}
+
+			event := runner.Event{Time: time.Now(), Input: input}
+			event.Output, event.Err = r.Perform(name, input, auth)
+
+			if o.record {
+				o.recordFixture(cmd.ErrOrStderr(), runner.KindAction, name, event)
+			}
+
+			if event.Err != nil {
+				return fmt.Errorf("action '%s' failed: %w", name, event.Err)
+			}
+
+			return printJSON(cmd.OutOrStdout(), event.Output)
+		},
+	}
+
+	registerRunFlags(cmd, o)
+	registerReplayFlag(cmd, o)
+
+	return cmd
+}
+
+type runTriggerOptions struct {
+	interval time.Duration
+	once     bool
+
+	runOptions
+}
+
+func newRunTriggerCmd(s *session) *cobra.Command {
+	o := &runTriggerOptions{runOptions: *defaultRunOptions()}
+
+	cmd := &cobra.Command{
+		Use:   "trigger <name>",
+		Short: "Run a trigger locally",
+		Long: "Use this command to build the current integration project and run one of its triggers the way Wakflo does. " +
+			"The trigger is started, then executed on the schedule of its Criteria(), every minute for polling triggers without one, " +
+			"with the time of the last successful execution kept between runs. Event and webhook triggers are executed once. " +
+			"Each payload is printed, and the trigger is stopped on Ctrl-C. " +
+			"With --record, each execution is saved as a fixture in 'testdata/triggers/<trigger>', and --replay executes the trigger " +
+			"again with the input and last run of every fixture, reporting the outputs that changed.",
+		Example: "  wakflo run trigger new_message --input input.json\n" +
+			"  wakflo run trigger new_message --interval 10s\n" +
+			"  wakflo run trigger daily_report --once --record\n" +
+			"  wakflo run trigger daily_report --replay",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			input, auth, err := o.read(cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			r, err := runner.Start(ctx, o.dir, cmd.ErrOrStderr())
+			if err != nil {
+				return err
+			}
+			defer r.Close()
+
+			name, err := r.ResolveName(runner.KindTrigger, args[0])
+			if err != nil {
+				return err
+			}
+
+			if o.replay {
+				return o.replayFixtures(cmd, r, runner.KindTrigger, name, auth)
+			}
+
+			if !o.once {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Running trigger '%s', press Ctrl-C to stop.\n", name)
+			}
+
+			return r.RunTrigger(ctx, name, runner.TriggerOptions{
+				Input:    input,
+				Auth:     auth,
+				Interval: o.interval,
+				Once:     o.once,
+			}, func(event runner.Event) {
+				printEvent(cmd.OutOrStdout(), cmd.ErrOrStderr(), event)
+
+				if o.record {
+					o.recordFixture(cmd.ErrOrStderr(), runner.KindTrigger, name, event)
+				}
+			})
+		},
+	}
+
+	registerRunFlags(cmd, &o.runOptions)
+	registerReplayFlag(cmd, &o.runOptions)
+	cmd.Flags().DurationVar(&o.interval, "interval", o.interval, "Execute the trigger at this interval instead of its schedule, e.g. '30s'")
+	cmd.Flags().BoolVar(&o.once, "once", o.once, "Execute the trigger once, then stop it")
+
+	return cmd
+}
+
+// printEvent prints the payload of a trigger execution to out, and when it
+// ran and runs again to log.
+func printEvent(out, log io.Writer, event runner.Event) {
+	const layout = "15:04:05"
+
+	if event.Err != nil {
+		fmt.Fprintf(log, "[%s] execution failed: %v\n", event.Time.Format(layout), event.Err)
+	} else {
+		fmt.Fprintf(log, "[%s] executed\n", event.Time.Format(layout))
+
+		if err := printJSON(out, event.Output); err != nil {
+			fmt.Fprintf(log, "invalid output: %v\n", err)
+		}
+	}
+
+	if !event.Next.IsZero() {
+		fmt.Fprintf(log, "next execution at %s\n", event.Next.Format(layout))
+	}
+}
+
+// fixturesDir returns the folder of the fixtures of the resource of kind
+// called name.
+func (o *runOptions) fixturesDir(kind, name string) string {
+	return runner.FixturesDir(fixturesRoot(o.dir, o.fixtures), kind, name)
+}
+
+// fixturesRoot returns the fixtures folder given by the --fixtures flag, or
+// the one of the project in dir.
+func fixturesRoot(dir, fixtures string) string {
+	if fixtures != "" {
+		return fixtures
+	}
+
+	return filepath.Join(dir, runner.DefaultFixturesDir)
+}
+
+// recordFixture saves event as a fixture of the resource, failing to do so
+// does not fail the execution.
+func (o *runOptions) recordFixture(log io.Writer, kind, name string, event runner.Event) {
+	f := runner.NewFixture(kind, name, event)
+
+	if err := f.Save(o.fixturesDir(kind, name)); err != nil {
+		fmt.Fprintf(log, "failed to record the fixture: %v\n", err)
+		return
+	}
+
+	fmt.Fprintf(log, "recorded %s\n", f.Path)
+}
+
+// replayFixtures replays the fixtures of the resource and fails when any
+// output changed.
+func (o *runOptions) replayFixtures(cmd *cobra.Command, r *runner.Runner, kind, name string, auth json.RawMessage) error {
+	replays, err := r.ReplayFixtures(kind, name, o.fixturesDir(kind, name), auth)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+
+	for _, replay := range replays {
+		if replay.Diff == "" {
+			fmt.Fprintf(cmd.OutOrStdout(), "ok    %s\n", replay.Fixture.Path)
+			continue
+		}
+
+		failed++
+
+		fmt.Fprintf(cmd.OutOrStdout(), "FAIL  %s\n%s", replay.Fixture.Path, replay.Diff)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d fixtures of %s '%s' do not match", failed, len(replays), kind, name)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "%d fixtures of %s '%s' replayed\n", len(replays), kind, name)
+
+	return nil
+}
+
+// read returns the content of the input and auth files.
+func (o *runOptions) read(stdin io.Reader) (input, auth json.RawMessage, err error) {
+	if o.input == "-" && o.auth == "-" {
+		return nil, nil, fmt.Errorf("only one of --input and --auth can be read from stdin")
+	}
+
+	if input, err = readJSONFile(o.input, stdin); err != nil {
+		return nil, nil, fmt.Errorf("failed to read the input: %w", err)
+	}
+
+	if auth, err = readJSONFile(o.auth, stdin); err != nil {
+		return nil, nil, fmt.Errorf("failed to read the auth: %w", err)
+	}
+
+	return input, auth, nil
+}
+
+// readJSONFile reads the JSON document in path, or stdin when path is "-".
+// An empty path returns nil.
+func readJSONFile(path string, stdin io.Reader) (json.RawMessage, error) {
+	var (
+		data []byte
+		err  error
+	)
+
+	switch path {
+	case "":
+		return nil, nil
+	case "-":
+		data, err = io.ReadAll(stdin)
+	default:
+		data, err = os.ReadFile(path)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !json.Valid(data) {
+		return nil, fmt.Errorf("'%s' is not valid JSON", path)
+	}
+
+	return bytes.TrimSpace(data), nil
+}
+
+func printJSON(w io.Writer, data json.RawMessage) error {
+	if len(data) == 0 {
+		data = json.RawMessage("null")
+	}
+
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, data, "", "  "); err != nil {
+		return err
+	}
+
+	buf.WriteByte('\n')
+
+	_, err := buf.WriteTo(w)
+
+	return err
+}
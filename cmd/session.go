@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/wakflo/go-sdk/client"
+	"github.com/wakflo/wakflo-cli/internal/auth"
+	"github.com/wakflo/wakflo-cli/internal/config"
+	"github.com/wakflo/wakflo-cli/internal/templates"
+)
+
+const (
+	envLocal  = "local"
+	envCustom = "custom"
+)
+
+// session carries the state shared by all commands: the selected profile,
+// its credentials and the API client authenticated with them. Everything is
+// resolved on first use so that offline commands never touch any of it.
+type session struct {
+	profileName string
+	storeKind   string
+	env         string
+	apiURL      string
+	offline     bool
+	dryRun      bool
+
+	profiles *auth.Profiles
+	profile  *auth.Profile
+	auth     *auth.Auth
+	client   *client.Client
+}
+
+func registerSessionFlags(cmd *cobra.Command, s *session) {
+	cmd.PersistentFlags().StringVar(&s.profileName, "profile", "", "Profile to use (defaults to $WAKFLO_PROFILE or the profile selected with 'wakflo auth switch')")
+	cmd.PersistentFlags().StringVar(&s.storeKind, "credential-store", "", "Credential store backend, 'file' or 'keyring' (defaults to $WAKFLO_CREDENTIAL_STORE or 'file')")
+	cmd.PersistentFlags().StringVar(&s.env, "env", "", "Wakflo API environment, e.g. 'local' or 'staging' (defaults to $WAKFLO_ENV)")
+	cmd.PersistentFlags().StringVar(&s.apiURL, "api-url", "", "Wakflo API base URL, overrides --env (defaults to $WAKFLO_API_URL)")
+	cmd.PersistentFlags().BoolVar(&s.offline, "offline", false, "Do not contact the Wakflo API, scaffold with local defaults instead")
+	cmd.PersistentFlags().BoolVar(&s.dryRun, "dry-run", false, "Print a diff of the files scaffolding commands would create or modify instead of writing them")
+}
+
+// Profiles returns all configured profiles.
+func (s *session) Profiles() (*auth.Profiles, error) {
+	if s.profiles != nil {
+		return s.profiles, nil
+	}
+
+	dir, err := auth.ConfigDir()
+	if err != nil {
+		return nil, err
+	}
+
+	profiles, err := auth.LoadProfiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	s.profiles = profiles
+
+	return s.profiles, nil
+}
+
+// Profile returns the active profile.
+func (s *session) Profile() (*auth.Profile, error) {
+	if s.profile != nil {
+		return s.profile, nil
+	}
+
+	profiles, err := s.Profiles()
+	if err != nil {
+		return nil, err
+	}
+
+	profile := profiles.Resolve(s.profileName)
+	if err := auth.ValidateProfileName(profile.Name); err != nil {
+		return nil, err
+	}
+
+	s.profile = profile
+
+	return s.profile, nil
+}
+
+// Auth returns the authenticator of the active profile pointed at the selected API.
+func (s *session) Auth() (*auth.Auth, error) {
+	if s.auth != nil {
+		return s.auth, nil
+	}
+
+	profile, err := s.Profile()
+	if err != nil {
+		return nil, err
+	}
+
+	env, apiURL, err := s.endpoint(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	a := auth.New(apiURL)
+	a.Environment = env
+	a.Profile = profile.Name
+	a.Workspace = profile.Workspace
+	a.StoreKind = s.storeKind
+	s.auth = a
+
+	return s.auth, nil
+}
+
+// Client returns the API client authenticated with the active profile.
+func (s *session) Client() (*client.Client, error) {
+	if s.client != nil {
+		return s.client, nil
+	}
+
+	a, err := s.Auth()
+	if err != nil {
+		return nil, err
+	}
+
+	floClient, err := client.New(
+		client.BaseURL(a.BaseURL),
+		client.WithHTTPClient(&http.Client{Transport: a.Transport(nil)}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create API client for %s: %w", a.BaseURL, err)
+	}
+
+	s.client = floClient
+
+	return s.client, nil
+}
+
+// Generator returns the source of scaffolding suggestions. Unless running
+// offline it is the API, falling back to local defaults when unreachable.
+func (s *session) Generator(warn io.Writer) templates.Generator {
+	if s.offline {
+		return templates.NewOfflineGenerator()
+	}
+
+	floClient, err := s.Client()
+	if err != nil {
+		fmt.Fprintf(warn, "Warning: %v, continuing offline with local defaults.\n", err)
+		return templates.NewOfflineGenerator()
+	}
+
+	return templates.NewFallbackGenerator(templates.NewAPIGenerator(floClient), warn)
+}
+
+// Project returns the project rooted at dir that scaffolding commands work in.
+func (s *session) Project(cmd *cobra.Command, dir string) *templates.Project {
+	p := templates.NewProject(dir)
+	p.DryRun = s.dryRun
+	p.Out = cmd.OutOrStdout()
+
+	return p
+}
+
+// Templates returns the template pack selected by the 'templates.source'
+// config, or the default templates.
+func (s *session) Templates(ctx context.Context) (*templates.Pack, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	cacheDir, err := config.CacheDir()
+	if err != nil {
+		return nil, err
+	}
+
+	return templates.LoadPack(ctx, cfg.Templates.Source, filepath.Join(cacheDir, "templates"))
+}
+
+// generatingProject returns the project of a command generating files, which
+// may overwrite existing ones with force and asks about them when it can prompt
+// and --yes was not given.
+func (s *session) generatingProject(cmd *cobra.Command, dir string, force bool, input *inputOptions) *templates.Project {
+	p := s.Project(cmd, dir)
+	p.Force = force
+	p.Interactive = input.promptDefaults()
+
+	return p
+}
+
+// endpoint picks the API to talk to. Flags win over environment variables,
+// which win over the profile, which wins over config.toml. An explicit URL
+// always wins over a named environment from the same source.
+func (s *session) endpoint(profile *auth.Profile) (string, string, error) {
+	if s.apiURL != "" {
+		return envCustom, s.apiURL, nil
+	}
+
+	if s.env != "" {
+		return s.env, environmentURL(s.env), nil
+	}
+
+	if url := os.Getenv(config.APIURLEnv); url != "" {
+		return envCustom, url, nil
+	}
+
+	if env := os.Getenv(config.EnvEnv); env != "" {
+		return env, environmentURL(env), nil
+	}
+
+	if profile.APIURL != "" {
+		return envCustom, profile.APIURL, nil
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return "", "", err
+	}
+
+	if cfg.API.URL != "" {
+		return envCustom, cfg.API.URL, nil
+	}
+
+	if cfg.API.Env != "" {
+		return cfg.API.Env, environmentURL(cfg.API.Env), nil
+	}
+
+	return envLocal, string(client.Local), nil
+}
+
+func environmentURL(env string) string {
+	if env == envLocal {
+		return string(client.Local)
+	}
+
+	return string(client.Environment(env))
+}
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wakflo/go-sdk/client"
+	"github.com/wakflo/wakflo-cli/internal/auth"
+	"github.com/wakflo/wakflo-cli/internal/config"
+)
+
+// isolateConfig points the user config dir at a temporary directory.
+func isolateConfig(t *testing.T) string {
+	t.Helper()
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", home)
+	t.Setenv("AppData", home)
+	t.Setenv(config.APIURLEnv, "")
+	t.Setenv(config.EnvEnv, "")
+	t.Setenv(auth.ProfileEnv, "")
+
+	dir, err := config.Dir()
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(dir, 0o700))
+
+	return dir
+}
+
+func TestSessionEndpoint(t *testing.T) {
+	testCases := []struct {
+		name       string
+		session    session
+		env        map[string]string
+		profileURL string
+		configFile string
+		wantEnv    string
+		wantURL    string
+	}{
+		{
+			name:    "defaults to local",
+			wantEnv: envLocal,
+			wantURL: string(client.Local),
+		},
+		{
+			name:       "config file url",
+			configFile: "[api]\nurl = \"https://config.wakflo.test\"\n",
+			wantEnv:    envCustom,
+			wantURL:    "https://config.wakflo.test",
+		},
+		{
+			name:       "profile wins over config file",
+			configFile: "[api]\nurl = \"https://config.wakflo.test\"\n",
+			profileURL: "https://profile.wakflo.test",
+			wantEnv:    envCustom,
+			wantURL:    "https://profile.wakflo.test",
+		},
+		{
+			name:       "env var wins over profile",
+			profileURL: "https://profile.wakflo.test",
+			env:        map[string]string{config.APIURLEnv: "https://env.wakflo.test"},
+			wantEnv:    envCustom,
+			wantURL:    "https://env.wakflo.test",
+		},
+		{
+			name:    "named environment flag",
+			session: session{env: "staging"},
+			wantEnv: "staging",
+			wantURL: string(client.Environment("staging")),
+		},
+		{
+			name:    "environment flag wins over api url env var",
+			session: session{env: "staging"},
+			env:     map[string]string{config.APIURLEnv: "https://env.wakflo.test"},
+			wantEnv: "staging",
+			wantURL: string(client.Environment("staging")),
+		},
+		{
+			name:    "api url flag wins over environment env var",
+			session: session{apiURL: "https://flag.wakflo.test"},
+			env:     map[string]string{config.EnvEnv: "staging"},
+			wantEnv: envCustom,
+			wantURL: "https://flag.wakflo.test",
+		},
+		{
+			name:    "api url flag wins over everything",
+			session: session{env: "staging", apiURL: "https://flag.wakflo.test"},
+			env:     map[string]string{config.APIURLEnv: "https://env.wakflo.test"},
+			wantEnv: envCustom,
+			wantURL: "https://flag.wakflo.test",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := isolateConfig(t)
+
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+
+			if tc.configFile != "" {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, "config.toml"), []byte(tc.configFile), 0o600))
+			}
+
+			env, url, err := tc.session.endpoint(&auth.Profile{Name: auth.DefaultProfile, APIURL: tc.profileURL})
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantEnv, env)
+			assert.Equal(t, tc.wantURL, url)
+		})
+	}
+}
+
+func TestOfflineCommandsDoNotNeedAClient(t *testing.T) {
+	dir := isolateConfig(t)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.toml"), []byte("not = [valid"), 0o600))
+
+	cmd := newRootCmd("v1.0.0")
+	cmd.SetArgs([]string{"version"})
+	cmd.SetOut(io.Discard)
+
+	require.NoError(t, cmd.Execute())
+}
+
+func TestSessionRejectsInvalidProfileNames(t *testing.T) {
+	testCases := []struct {
+		name    string
+		session session
+		env     string
+	}{
+		{
+			name:    "flag",
+			session: session{profileName: "../other"},
+		},
+		{
+			name: "env var",
+			env:  "a/b",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			isolateConfig(t)
+			t.Setenv(auth.ProfileEnv, tc.env)
+
+			_, err := tc.session.Profile()
+			require.ErrorContains(t, err, "invalid profile name")
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"github.com/wakflo/wakflo-cli/internal/config"
+	"github.com/wakflo/wakflo-cli/internal/templates"
+)
+
+func newTemplatesCmd(s *session) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "templates",
+		Short: "Inspect and customize scaffolding templates",
+		Long: "Use this command to inspect the templates 'create' and 'add' generate files from, and to export the built-in ones " +
+			"as a template pack to customize. The pack in use is set by the 'templates.source' config, which is only read from " +
+			"the project-level config when " + config.ProjectTemplatesEnv + " is set to true.",
+	}
+
+	var listOutput string
+
+	templatesListCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the files generated from templates",
+		Long:  "Use this command to list the files generated for integrations, actions and triggers, and the templates they are rendered from.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pack, err := s.Templates(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			files := pack.Files()
+
+			return printOutput(cmd.OutOrStdout(), listOutput, files, func(w io.Writer) {
+				tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+				fmt.Fprintln(tw, "KIND\tPATH\tTEMPLATE\tPACK")
+
+				for _, file := range files {
+					fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", file.Kind, file.Path, file.Template, file.Pack)
+				}
+
+				tw.Flush()
+			})
+		},
+	}
+
+	registerOutputFlag(templatesListCmd, &listOutput)
+
+	templatesShowCmd := &cobra.Command{
+		Use:          "show <template>",
+		Short:        "Print a template",
+		Long:         "Use this command to print a template listed by 'wakflo templates list'.",
+		Example:      "  wakflo templates show action.go.tmpl",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pack, err := s.Templates(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			files := pack.Files()
+
+			file, ok := lo.Find(files, func(file templates.PackFile) bool { return file.Template == args[0] })
+			if !ok {
+				names := lo.Uniq(lo.Map(files, func(file templates.PackFile, _ int) string { return file.Template }))
+				return fmt.Errorf("unknown template '%s', expected one of: %s", args[0], strings.Join(names, ", "))
+			}
+
+			source, err := file.Source()
+			if err != nil {
+				return err
+			}
+
+			_, err = cmd.OutOrStdout().Write(source)
+
+			return err
+		},
+	}
+
+	var exportForce bool
+
+	templatesExportCmd := &cobra.Command{
+		Use:   "export <dir>",
+		Short: "Export the built-in templates",
+		Long: "Use this command to copy the built-in templates and their manifest into a directory. Edit them, then run " +
+			"'wakflo config set templates.source <dir>' to scaffold with them.",
+		Example:      "  wakflo templates export ./templates",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p := s.Project(cmd, args[0])
+			p.Force = exportForce
+
+			changes, err := templates.ExportPack(templates.DefaultPack(), p)
+			if err != nil {
+				return err
+			}
+
+			if s.dryRun {
+				return nil
+			}
+
+			for _, change := range changes {
+				fmt.Fprintf(cmd.OutOrStdout(), "  %s\n", change)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Templates exported to '%s', run 'wakflo config set templates.source %s' to use them.\n", args[0], args[0])
+
+			return nil
+		},
+	}
+
+	templatesExportCmd.Flags().BoolVarP(&exportForce, "force", "f", exportForce, "Overwrite files already in the directory")
+
+	cmd.AddCommand(templatesListCmd)
+	cmd.AddCommand(templatesShowCmd)
+	cmd.AddCommand(templatesExportCmd)
+
+	return cmd
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"github.com/wakflo/wakflo-cli/internal/runner"
+)
+
+func newVerifyCmd(s *session) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Verify resources against recorded runs",
+		Long:  "Use this command to check the resources of the current integration project against the fixtures recorded with 'wakflo run --record'.",
+	}
+
+	cmd.AddCommand(newVerifySamplesCmd(s))
+
+	return cmd
+}
+
+type verifySamplesOptions struct {
+	dir      string
+	auth     string
+	fixtures string
+	output   string
+}
+
+func newVerifySamplesCmd(s *session) *cobra.Command {
+	o := &verifySamplesOptions{dir: "."}
+
+	cmd := &cobra.Command{
+		Use:   "samples",
+		Short: "Compare SampleData() with actual outputs",
+		Long: "Use this command to run every action and trigger of the current integration project with the input of its fixtures, " +
+			"and compare the structure of the outputs with what its SampleData() returns. Keys missing on either side and values " +
+			"of different types are reported, resources without fixtures are skipped.",
+		Example: "  wakflo run action send_message --input input.json --record\n" +
+			"  wakflo verify samples",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			auth, err := readJSONFile(o.auth, cmd.InOrStdin())
+			if err != nil {
+				return fmt.Errorf("failed to read the auth: %w", err)
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			r, err := runner.Start(ctx, o.dir, cmd.ErrOrStderr())
+			if err != nil {
+				return err
+			}
+			defer r.Close()
+
+			// Ctrl-C aborts the replays, triggers are not stopped
+			defer context.AfterFunc(ctx, func() { _ = r.Kill() })()
+
+			reports, err := r.VerifySamples(fixturesRoot(o.dir, o.fixtures), auth)
+			if err != nil {
+				return err
+			}
+
+			if err := printOutput(cmd.OutOrStdout(), o.output, reports, func(w io.Writer) {
+				printSampleReports(w, reports)
+			}); err != nil {
+				return err
+			}
+
+			failed := 0
+			for _, report := range reports {
+				if !report.OK() {
+					failed++
+				}
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("the sample data of %d resources does not match their outputs", failed)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&o.dir, "dir", o.dir, "Directory of the integration project")
+	cmd.Flags().StringVar(&o.auth, "auth", o.auth, "JSON file with the auth context, '-' to read it from stdin")
+	cmd.Flags().StringVar(&o.fixtures, "fixtures", o.fixtures, "Directory of the fixtures (defaults to 'testdata' in the project)")
+	registerOutputFlag(cmd, &o.output)
+
+	return cmd
+}
+
+func printSampleReports(w io.Writer, reports []runner.SampleReport) {
+	for _, report := range reports {
+		switch {
+		case report.Fixtures == 0 && report.OK():
+			fmt.Fprintf(w, "skip  %s '%s': no fixtures\n", report.Kind, report.Name)
+		case report.OK():
+			fmt.Fprintf(w, "ok    %s '%s'\n", report.Kind, report.Name)
+		default:
+			fmt.Fprintf(w, "FAIL  %s '%s'\n", report.Kind, report.Name)
+		}
+
+		for _, mismatch := range report.Mismatches {
+			fmt.Fprintf(w, "      %s\n", mismatch)
+		}
+
+		for _, err := range report.Errors {
+			fmt.Fprintf(w, "      %s\n", err)
+		}
+	}
+}
@@ -1,37 +1,226 @@
 package auth
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"os"
+	"time"
+
 	"github.com/spf13/cobra"
 )
 
+const (
+	// DefaultBaseURL is the Wakflo API used when no other endpoint is configured.
+	DefaultBaseURL = "http://localhost:4000"
+	// DefaultClientID is the OAuth client registered for the CLI.
+	DefaultClientID = "wakflo-cli"
+	// WorkspaceHeader selects the workspace API requests act on.
+	WorkspaceHeader = "X-Wakflo-Workspace"
+)
+
 type Auth struct {
+	// Environment names the API environment BaseURL belongs to, for display only.
+	Environment string
+	BaseURL     string
+	ClientID    string
+	Scopes      []string
+	HTTPClient  *http.Client
+
+	// Profile names the credentials in use, Workspace is sent along with API requests.
+	Profile   string
+	Workspace string
+
+	// Store persists credentials between runs. When nil the store named by
+	// StoreKind (or WAKFLO_CREDENTIAL_STORE) is used for Profile.
+	Store     Store
+	StoreKind string
+
+	// NoBrowser disables opening the verification URL automatically.
+	NoBrowser bool
+
+	pollInterval time.Duration
 }
 
-func New() *Auth {
-	return &Auth{}
+func New(baseURL string) *Auth {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+
+	return &Auth{
+		BaseURL:    baseURL,
+		Profile:    DefaultProfile,
+		ClientID:   DefaultClientID,
+		Scopes:     []string{"openid", "profile", "offline_access"},
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+	}
 }
 
-func (a *Auth) Login(cmd *cobra.Command) {
-	// Add login logic
-	fmt.Println("Logged in successfully!")
+// Login runs the OAuth 2.0 device authorization flow and persists the issued token.
+func (a *Auth) Login(cmd *cobra.Command) error {
+	ctx := commandContext(cmd)
+
+	code, err := a.RequestDeviceCode(ctx)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "Open %s in your browser and enter the code: %s\n", code.VerificationURI, code.UserCode)
+
+	if !a.NoBrowser {
+		target := code.VerificationURIComplete
+		if target == "" {
+			target = code.VerificationURI
+		}
+
+		if err := openBrowser(target); err != nil {
+			fmt.Fprintln(out, "Could not open a browser automatically, please open the URL manually.")
+		}
+	}
+
+	fmt.Fprintln(out, "Waiting for authorization...")
+
+	token, err := a.PollToken(ctx, code)
+	if err != nil {
+		return err
+	}
+
+	if err := a.SetToken(token); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out, "Logged in successfully!")
+
+	return nil
 }
 
-func (a *Auth) Logout(cmd *cobra.Command) {
-	// Add login logic
-	fmt.Println("Logged out successfully!")
+func (a *Auth) Logout(cmd *cobra.Command) error {
+	store, err := a.store()
+	if err != nil {
+		return err
+	}
+
+	if err := store.Delete(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), "Logged out successfully!")
+
+	if os.Getenv(TokenEnv) != "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "Note: %s is still set and will keep being used.\n", TokenEnv)
+	}
+
+	return nil
 }
 
 func (a *Auth) IsLoggedIn() bool {
-	return false
+	return a.GetToken() != ""
 }
 
+// GetToken returns a valid access token or "" when there is no usable session.
 func (a *Auth) GetToken() string {
-	return ""
+	token, err := a.Token(context.Background())
+	if err != nil {
+		return ""
+	}
+
+	return token.AccessToken
+}
+
+// Token returns the current session token. WAKFLO_TOKEN takes precedence over
+// stored credentials, and an expired stored token is refreshed and saved back.
+func (a *Auth) Token(ctx context.Context) (*Token, error) {
+	if token, err := (EnvStore{}).Load(); err == nil {
+		return token, nil
+	}
+
+	store, err := a.store()
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := store.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	if token.Valid() {
+		return token, nil
+	}
+
+	refreshed, err := a.Refresh(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := store.Save(refreshed); err != nil {
+		return nil, fmt.Errorf("failed to save refreshed token: %w", err)
+	}
+
+	return refreshed, nil
+}
+
+// SetToken persists token in the credential store.
+func (a *Auth) SetToken(token *Token) error {
+	store, err := a.store()
+	if err != nil {
+		return err
+	}
+
+	if err := store.Save(token); err != nil {
+		return fmt.Errorf("failed to save token: %w", err)
+	}
+
+	return nil
+}
+
+func (a *Auth) store() (Store, error) {
+	if a.Store == nil {
+		store, err := NewStore(a.StoreKind, a.Profile)
+		if err != nil {
+			return nil, err
+		}
+
+		a.Store = store
+	}
+
+	return a.Store, nil
+}
+
+// Transport returns a round tripper that authenticates requests with the
+// current session token and the profile's default workspace.
+func (a *Auth) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &tokenTransport{auth: a, base: base}
+}
+
+type tokenTransport struct {
+	auth *Auth
+	base http.RoundTripper
+}
+
+func (t *tokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+
+	if token := t.auth.GetToken(); token != "" && req.Header.Get("Authorization") == "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	if t.auth.Workspace != "" {
+		req.Header.Set(WorkspaceHeader, t.auth.Workspace)
+	}
+
+	return t.base.RoundTrip(req)
 }
 
-func (a *Auth) SetToken(token string) {}
+func commandContext(cmd *cobra.Command) context.Context {
+	if ctx := cmd.Context(); ctx != nil {
+		return ctx
+	}
 
-func (a *Auth) WhoAMI(cmd *cobra.Command) string {
-	return ""
+	return context.Background()
 }
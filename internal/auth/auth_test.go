@@ -0,0 +1,210 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newStubServer returns an authorization server that answers with pending
+// responses pendingPolls times before issuing a token or the given final error.
+func newStubServer(t *testing.T, pendingPolls int, finalErr string) *httptest.Server {
+	t.Helper()
+
+	polls := 0
+	mux := http.NewServeMux()
+
+	mux.HandleFunc(deviceCodePath, func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, DefaultClientID, r.PostForm.Get("client_id"))
+
+		_ = json.NewEncoder(w).Encode(DeviceCode{
+			DeviceCode:      "device-123",
+			UserCode:        "ABCD-EFGH",
+			VerificationURI: "https://wakflo.test/activate",
+			ExpiresIn:       60,
+			Interval:        1,
+		})
+	})
+
+	mux.HandleFunc(tokenPath, func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, deviceGrantType, r.PostForm.Get("grant_type"))
+		assert.Equal(t, "device-123", r.PostForm.Get("device_code"))
+
+		polls++
+		if polls <= pendingPolls {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(oauthError{Code: "authorization_pending"})
+
+			return
+		}
+
+		if finalErr != "" {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(oauthError{Code: finalErr})
+
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(Token{
+			AccessToken:  "access-123",
+			TokenType:    "Bearer",
+			RefreshToken: "refresh-123",
+			ExpiresIn:    3600,
+		})
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func newTestAuth(t *testing.T, baseURL string) *Auth {
+	t.Helper()
+
+	// keep the developer's own session out of the tests
+	t.Setenv(TokenEnv, "")
+
+	a := New(baseURL)
+	a.NoBrowser = true
+	a.Store = NewFileStore(t.TempDir())
+	a.pollInterval = time.Millisecond
+
+	return a
+}
+
+func TestLogin(t *testing.T) {
+	testCases := []struct {
+		name     string
+		pending  int
+		finalErr string
+		err      error
+	}{
+		{
+			name:    "approved after pending polls",
+			pending: 2,
+		},
+		{
+			name:     "denied",
+			pending:  1,
+			finalErr: "access_denied",
+			err:      ErrAccessDenied,
+		},
+		{
+			name:     "expired",
+			finalErr: "expired_token",
+			err:      ErrExpiredToken,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := newStubServer(t, tc.pending, tc.finalErr)
+			a := newTestAuth(t, srv.URL)
+
+			cmd := &cobra.Command{}
+			b := bytes.NewBufferString("")
+			cmd.SetOut(b)
+
+			err := a.Login(cmd)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				assert.False(t, a.IsLoggedIn())
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Contains(t, b.String(), "ABCD-EFGH")
+			assert.Contains(t, b.String(), "https://wakflo.test/activate")
+
+			// a fresh instance must pick up the persisted token
+			reloaded := newTestAuth(t, srv.URL)
+			reloaded.Store = a.Store
+			assert.True(t, reloaded.IsLoggedIn())
+			assert.Equal(t, "access-123", reloaded.GetToken())
+
+			require.NoError(t, reloaded.Logout(cmd))
+
+			loggedOut := newTestAuth(t, srv.URL)
+			loggedOut.Store = a.Store
+			assert.False(t, loggedOut.IsLoggedIn())
+		})
+	}
+}
+
+func TestPollTokenExpiresInFlight(t *testing.T) {
+	release := make(chan struct{})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// answer after the device code expired
+		<-release
+	}))
+	t.Cleanup(srv.Close)
+	t.Cleanup(func() { close(release) })
+
+	a := newTestAuth(t, srv.URL)
+
+	_, err := a.PollToken(context.Background(), &DeviceCode{DeviceCode: "device-123", ExpiresIn: 1})
+	require.ErrorIs(t, err, ErrExpiredToken)
+}
+
+func TestWhoAMIAndStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, whoamiPath, r.URL.Path)
+
+		if r.Header.Get("Authorization") != "Bearer access-123" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(Identity{
+			Account:   Account{ID: "acc_1", Email: "dev@wakflo.test"},
+			Workspace: &Workspace{ID: "ws_1", Name: "Sandbox"},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	a := newTestAuth(t, srv.URL)
+
+	_, err := a.WhoAMI(context.Background())
+	require.ErrorIs(t, err, ErrNotLoggedIn)
+
+	status, err := a.Status(context.Background())
+	require.NoError(t, err)
+	assert.False(t, status.LoggedIn)
+
+	expiry := time.Now().Add(time.Hour)
+	require.NoError(t, a.SetToken(&Token{AccessToken: "access-123", Expiry: expiry}))
+
+	identity, err := a.WhoAMI(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "dev@wakflo.test", identity.Account.Email)
+	assert.Equal(t, "Sandbox", identity.Workspace.Name)
+
+	status, err = a.Status(context.Background())
+	require.NoError(t, err)
+	assert.True(t, status.LoggedIn)
+	assert.Equal(t, StoreFile, status.TokenSource)
+	assert.Equal(t, "acc_1", status.Account.ID)
+	assert.Empty(t, status.Error)
+	require.NotNil(t, status.ExpiresAt)
+	assert.True(t, expiry.Equal(*status.ExpiresAt))
+
+	require.NoError(t, a.SetToken(&Token{AccessToken: "revoked"}))
+
+	status, err = a.Status(context.Background())
+	require.NoError(t, err)
+	assert.True(t, status.LoggedIn)
+	assert.Contains(t, status.Error, "rejected")
+}
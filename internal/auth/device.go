@@ -0,0 +1,160 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	deviceCodePath  = "/oauth/device/code"
+	tokenPath       = "/oauth/token"
+	deviceGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+	defaultPollInterval = 5 * time.Second
+	slowDownIncrement   = 5 * time.Second
+)
+
+var (
+	ErrAccessDenied = errors.New("authorization request was denied")
+	ErrExpiredToken = errors.New("device code expired before authorization completed")
+)
+
+// DeviceCode is the response of the device authorization endpoint (RFC 8628, section 3.2).
+type DeviceCode struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete,omitempty"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval,omitempty"`
+}
+
+type oauthError struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+}
+
+func (e *oauthError) Error() string {
+	if e.Description != "" {
+		return fmt.Sprintf("%s: %s", e.Code, e.Description)
+	}
+
+	return e.Code
+}
+
+// RequestDeviceCode starts a device authorization flow against the Wakflo API.
+func (a *Auth) RequestDeviceCode(ctx context.Context) (*DeviceCode, error) {
+	form := url.Values{
+		"client_id": {a.ClientID},
+		"scope":     {strings.Join(a.Scopes, " ")},
+	}
+
+	var code DeviceCode
+	if err := a.postForm(ctx, deviceCodePath, form, &code); err != nil {
+		return nil, fmt.Errorf("failed to request device code: %w", err)
+	}
+
+	if code.DeviceCode == "" || code.UserCode == "" || code.VerificationURI == "" {
+		return nil, errors.New("failed to request device code: incomplete response from authorization server")
+	}
+
+	return &code, nil
+}
+
+// PollToken polls the token endpoint until the user approves or denies the device code,
+// the code expires or ctx is cancelled.
+func (a *Auth) PollToken(ctx context.Context, code *DeviceCode) (*Token, error) {
+	interval := time.Duration(code.Interval) * time.Second
+	if a.pollInterval > 0 {
+		interval = a.pollInterval
+	} else if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	if code.ExpiresIn > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(code.ExpiresIn)*time.Second)
+		defer cancel()
+	}
+
+	form := url.Values{
+		"grant_type":  {deviceGrantType},
+		"device_code": {code.DeviceCode},
+		"client_id":   {a.ClientID},
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, ErrExpiredToken
+			}
+
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+
+		var token Token
+		err := a.postForm(ctx, tokenPath, form, &token)
+		if err == nil {
+			token.setExpiry()
+
+			return &token, nil
+		}
+
+		// the code expired while the request was in flight
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, ErrExpiredToken
+		}
+
+		var oerr *oauthError
+		if !errors.As(err, &oerr) {
+			return nil, fmt.Errorf("failed to poll for token: %w", err)
+		}
+
+		switch oerr.Code {
+		case "authorization_pending":
+		case "slow_down":
+			interval += slowDownIncrement
+		case "access_denied":
+			return nil, ErrAccessDenied
+		case "expired_token":
+			return nil, ErrExpiredToken
+		default:
+			return nil, fmt.Errorf("failed to poll for token: %w", oerr)
+		}
+	}
+}
+
+func (a *Auth) postForm(ctx context.Context, path string, form url.Values, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(a.BaseURL, "/")+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := a.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		var oerr oauthError
+		if err := json.NewDecoder(resp.Body).Decode(&oerr); err != nil || oerr.Code == "" {
+			return fmt.Errorf("unexpected response status %s", resp.Status)
+		}
+
+		return &oerr
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
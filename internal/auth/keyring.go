@@ -0,0 +1,136 @@
+package auth
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// securityItemNotFound is the exit code of `security` for errSecItemNotFound.
+const securityItemNotFound = 44
+
+var errKeyringUnsupported = fmt.Errorf("the OS keyring is not supported on %s, use the '%s' credential store", runtime.GOOS, StoreFile)
+
+// KeyringStore keeps the token in the OS keyring through the platform's
+// command line helper (`security` on macOS, `secret-tool` on Linux).
+type KeyringStore struct {
+	Service string
+	Account string
+}
+
+// NewKeyringStore returns a keyring store holding the token of profile under service.
+func NewKeyringStore(service, profile string) *KeyringStore {
+	if profile == "" {
+		profile = DefaultProfile
+	}
+
+	return &KeyringStore{Service: service, Account: profile}
+}
+
+func (s *KeyringStore) Load() (*Token, error) {
+	var cmd *exec.Cmd
+
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("security", "find-generic-password", "-s", s.Service, "-a", s.Account, "-w")
+	case "linux":
+		cmd = exec.Command("secret-tool", "lookup", "service", s.Service, "account", s.Account)
+	default:
+		return nil, errKeyringUnsupported
+	}
+
+	out, err := cmd.Output()
+	if itemNotFound(err) || (err == nil && len(bytes.TrimSpace(out)) == 0) {
+		return nil, ErrNoCredentials
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keyring: %w", keyringError(err))
+	}
+
+	var token Token
+	if err := json.Unmarshal(bytes.TrimSpace(out), &token); err != nil {
+		return nil, fmt.Errorf("failed to parse keyring credentials: %w", err)
+	}
+
+	return &token, nil
+}
+
+func (s *KeyringStore) Save(token *Token) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+
+	var cmd *exec.Cmd
+
+	switch runtime.GOOS {
+	case "darwin":
+		// the token goes through stdin, as arguments are visible to other users
+		cmd = exec.Command("security", "-i")
+		cmd.Stdin = strings.NewReader(fmt.Sprintf("add-generic-password -U -s %q -a %q -X %s\n", s.Service, s.Account, hex.EncodeToString(data)))
+	case "linux":
+		cmd = exec.Command("secret-tool", "store", "--label=Wakflo CLI", "service", s.Service, "account", s.Account)
+		cmd.Stdin = bytes.NewReader(data)
+	default:
+		return errKeyringUnsupported
+	}
+
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to write keyring: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+
+	return nil
+}
+
+func (s *KeyringStore) Delete() error {
+	var cmd *exec.Cmd
+
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("security", "delete-generic-password", "-s", s.Service, "-a", s.Account)
+	case "linux":
+		cmd = exec.Command("secret-tool", "clear", "service", s.Service, "account", s.Account)
+	default:
+		return errKeyringUnsupported
+	}
+
+	// deleting an entry that does not exist is not an error
+	if _, err := cmd.Output(); err != nil && !itemNotFound(err) {
+		return fmt.Errorf("failed to clear keyring: %w", keyringError(err))
+	}
+
+	return nil
+}
+
+// itemNotFound reports whether err is the keyring helper failing because the
+// entry does not exist, rather than for instance a locked keyring.
+func itemNotFound(err error) bool {
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return false
+	}
+
+	switch runtime.GOOS {
+	case "darwin":
+		return exitErr.ExitCode() == securityItemNotFound
+	default:
+		// secret-tool exits with 1 and says nothing when there is no match
+		return exitErr.ExitCode() == 1 && len(bytes.TrimSpace(exitErr.Stderr)) == 0
+	}
+}
+
+// keyringError adds what the keyring helper printed to err.
+func keyringError(err error) error {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(bytes.TrimSpace(exitErr.Stderr)) > 0 {
+		return fmt.Errorf("%w: %s", err, bytes.TrimSpace(exitErr.Stderr))
+	}
+
+	return err
+}
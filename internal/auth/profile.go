@@ -0,0 +1,155 @@
+package auth
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/BurntSushi/toml"
+)
+
+const (
+	// DefaultProfile is used when no profile has been selected.
+	DefaultProfile = "default"
+	// ProfileEnv selects the active profile, taking precedence over 'wakflo auth switch'.
+	ProfileEnv = "WAKFLO_PROFILE"
+
+	profilesFile = "profiles.toml"
+)
+
+// profileNameRe matches the names a profile can have, which are used as folder
+// names and keyring accounts.
+var profileNameRe = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// ValidateProfileName returns an error when name is not made of letters,
+// digits, dashes and underscores only.
+func ValidateProfileName(name string) error {
+	if !profileNameRe.MatchString(name) {
+		return fmt.Errorf("invalid profile name '%s', use letters, digits, '-' and '_' only", name)
+	}
+
+	return nil
+}
+
+// Profile is a named set of credentials and API settings, e.g. "sandbox", "staging" or "production".
+type Profile struct {
+	Name      string `toml:"-" json:"name"`
+	APIURL    string `toml:"api_url" json:"api_url"`
+	Workspace string `toml:"workspace,omitempty" json:"workspace,omitempty"`
+}
+
+// Profiles is the content of profiles.toml in the user config dir.
+type Profiles struct {
+	Active   string              `toml:"active"`
+	Profiles map[string]*Profile `toml:"profiles"`
+
+	path string
+}
+
+// LoadProfiles reads the profiles kept in dir. A missing file yields an empty set.
+func LoadProfiles(dir string) (*Profiles, error) {
+	p := &Profiles{Profiles: map[string]*Profile{}, path: filepath.Join(dir, profilesFile)}
+
+	data, err := os.ReadFile(p.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return p, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profiles: %w", err)
+	}
+
+	if err := toml.Unmarshal(data, p); err != nil {
+		return nil, fmt.Errorf("failed to parse '%s': %w", p.path, err)
+	}
+
+	if p.Profiles == nil {
+		p.Profiles = map[string]*Profile{}
+	}
+
+	for name, profile := range p.Profiles {
+		profile.Name = name
+	}
+
+	return p, nil
+}
+
+// Save writes the profiles back to the file they were loaded from.
+func (p *Profiles) Save() error {
+	if err := os.MkdirAll(filepath.Dir(p.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create config dir: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(p); err != nil {
+		return fmt.Errorf("failed to encode profiles: %w", err)
+	}
+
+	return os.WriteFile(p.path, buf.Bytes(), 0o600)
+}
+
+// Resolve returns the profile to use. An explicit name wins over WAKFLO_PROFILE,
+// which wins over the profile chosen with 'wakflo auth switch'. Unknown names
+// resolve to a fresh profile without an API URL so that it can be logged into.
+func (p *Profiles) Resolve(name string) *Profile {
+	if name == "" {
+		name = os.Getenv(ProfileEnv)
+	}
+
+	if name == "" {
+		name = p.Active
+	}
+
+	if name == "" {
+		name = DefaultProfile
+	}
+
+	if profile, ok := p.Profiles[name]; ok {
+		return profile
+	}
+
+	return &Profile{Name: name}
+}
+
+// Set adds or replaces a profile.
+func (p *Profiles) Set(profile *Profile) {
+	p.Profiles[profile.Name] = profile
+}
+
+// Use makes name the active profile.
+func (p *Profiles) Use(name string) error {
+	if _, ok := p.Profiles[name]; !ok {
+		return fmt.Errorf("unknown profile '%s', log in with 'wakflo auth login --profile %s' first", name, name)
+	}
+
+	p.Active = name
+
+	return nil
+}
+
+// Remove deletes a profile, resetting the active one if needed.
+func (p *Profiles) Remove(name string) {
+	delete(p.Profiles, name)
+
+	if p.Active == name {
+		p.Active = ""
+	}
+}
+
+// List returns all profiles sorted by name.
+func (p *Profiles) List() []*Profile {
+	list := make([]*Profile, 0, len(p.Profiles))
+	for _, profile := range p.Profiles {
+		list = append(list, profile)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+
+	return list
+}
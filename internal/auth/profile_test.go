@@ -0,0 +1,72 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfiles(t *testing.T) {
+	t.Setenv(ProfileEnv, "")
+
+	dir := t.TempDir()
+
+	profiles, err := LoadProfiles(dir)
+	require.NoError(t, err)
+	assert.Empty(t, profiles.List())
+	assert.Equal(t, &Profile{Name: DefaultProfile}, profiles.Resolve(""))
+
+	require.Error(t, profiles.Use("staging"))
+
+	profiles.Set(&Profile{Name: "staging", APIURL: "https://staging.wakflo.test", Workspace: "qa"})
+	profiles.Set(&Profile{Name: "production", APIURL: "https://api.wakflo.test"})
+	require.NoError(t, profiles.Use("staging"))
+	require.NoError(t, profiles.Save())
+
+	reloaded, err := LoadProfiles(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"production", "staging"}, []string{reloaded.List()[0].Name, reloaded.List()[1].Name})
+
+	active := reloaded.Resolve("")
+	assert.Equal(t, "staging", active.Name)
+	assert.Equal(t, "https://staging.wakflo.test", active.APIURL)
+	assert.Equal(t, "qa", active.Workspace)
+
+	assert.Equal(t, "production", reloaded.Resolve("production").Name)
+
+	t.Setenv(ProfileEnv, "production")
+	assert.Equal(t, "production", reloaded.Resolve("").Name)
+	assert.Equal(t, "staging", reloaded.Resolve("staging").Name)
+
+	reloaded.Remove("staging")
+	assert.Empty(t, reloaded.Active)
+}
+
+func TestProfileStoresAreIsolated(t *testing.T) {
+	dir := t.TempDir()
+
+	sandbox := NewFileStore(profileDir(dir, DefaultProfile))
+	staging := NewFileStore(profileDir(dir, "staging"))
+
+	require.NoError(t, sandbox.Save(&Token{AccessToken: "sandbox"}))
+	require.NoError(t, staging.Save(&Token{AccessToken: "staging"}))
+
+	token, err := sandbox.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "sandbox", token.AccessToken)
+
+	token, err = staging.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "staging", token.AccessToken)
+}
+
+func TestValidateProfileName(t *testing.T) {
+	for _, name := range []string{"default", "staging-eu", "prod_2"} {
+		assert.NoError(t, ValidateProfileName(name), name)
+	}
+
+	for _, name := range []string{"", "../escape", "a/b", "with space", ".", "é"} {
+		assert.Error(t, ValidateProfileName(name), name)
+	}
+}
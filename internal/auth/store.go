@@ -0,0 +1,144 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/wakflo/wakflo-cli/internal/config"
+)
+
+const (
+	// TokenEnv overrides any stored credentials with a static access token.
+	TokenEnv = "WAKFLO_TOKEN"
+	// StoreEnv selects the credential store backend ("file" or "keyring").
+	StoreEnv = "WAKFLO_CREDENTIAL_STORE"
+
+	StoreFile    = "file"
+	StoreKeyring = "keyring"
+
+	credentialsFile = "credentials.json"
+)
+
+// ErrNoCredentials is returned by a Store that holds no token.
+var ErrNoCredentials = errors.New("no stored credentials")
+
+// Store persists the token of a logged-in session.
+type Store interface {
+	Load() (*Token, error)
+	Save(token *Token) error
+	Delete() error
+}
+
+// NewStore returns the credential store of profile selected by kind. An empty kind
+// uses the value of WAKFLO_CREDENTIAL_STORE and falls back to the file store.
+func NewStore(kind, profile string) (Store, error) {
+	if kind == "" {
+		kind = os.Getenv(StoreEnv)
+	}
+
+	switch kind {
+	case "", StoreFile:
+		dir, err := ConfigDir()
+		if err != nil {
+			return nil, err
+		}
+
+		return NewFileStore(profileDir(dir, profile)), nil
+	case StoreKeyring:
+		return NewKeyringStore(DefaultClientID, profile), nil
+	default:
+		return nil, fmt.Errorf("unknown credential store '%s', expected '%s' or '%s'", kind, StoreFile, StoreKeyring)
+	}
+}
+
+// ConfigDir returns the directory holding the CLI's user-level state.
+func ConfigDir() (string, error) {
+	return config.Dir()
+}
+
+// profileDir keeps the default profile's credentials at the top of dir
+// and every other profile in its own sub-directory.
+func profileDir(dir, profile string) string {
+	if profile == "" || profile == DefaultProfile {
+		return dir
+	}
+
+	return filepath.Join(dir, "profiles", profile)
+}
+
+// FileStore keeps the token as JSON in a file only readable by the current user.
+type FileStore struct {
+	Path string
+}
+
+func NewFileStore(dir string) *FileStore {
+	return &FileStore{Path: filepath.Join(dir, credentialsFile)}
+}
+
+func (s *FileStore) Load() (*Token, error) {
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNoCredentials
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credentials: %w", err)
+	}
+
+	var token Token
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, fmt.Errorf("failed to parse credentials '%s': %w", s.Path, err)
+	}
+
+	return &token, nil
+}
+
+func (s *FileStore) Save(token *Token) error {
+	if err := os.MkdirAll(filepath.Dir(s.Path), 0o700); err != nil {
+		return fmt.Errorf("failed to create credentials dir: %w", err)
+	}
+
+	data, err := json.MarshalIndent(token, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// write to a sibling file first so a crash never leaves truncated credentials behind
+	tmp := s.Path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write credentials: %w", err)
+	}
+
+	return os.Rename(tmp, s.Path)
+}
+
+func (s *FileStore) Delete() error {
+	if err := os.Remove(s.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove credentials: %w", err)
+	}
+
+	return nil
+}
+
+// EnvStore exposes the token from WAKFLO_TOKEN. It is read-only.
+type EnvStore struct{}
+
+func (EnvStore) Load() (*Token, error) {
+	token := os.Getenv(TokenEnv)
+	if token == "" {
+		return nil, ErrNoCredentials
+	}
+
+	return &Token{AccessToken: token, TokenType: "Bearer"}, nil
+}
+
+func (EnvStore) Save(*Token) error {
+	return fmt.Errorf("credentials are provided by %s and cannot be changed", TokenEnv)
+}
+
+func (EnvStore) Delete() error {
+	return fmt.Errorf("credentials are provided by %s, unset it to log out", TokenEnv)
+}
@@ -0,0 +1,147 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileStore(t *testing.T) {
+	store := NewFileStore(t.TempDir())
+
+	_, err := store.Load()
+	require.ErrorIs(t, err, ErrNoCredentials)
+
+	token := &Token{AccessToken: "access", RefreshToken: "refresh", Expiry: time.Now().Add(time.Hour).Round(0)}
+	require.NoError(t, store.Save(token))
+
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(store.Path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	}
+
+	loaded, err := store.Load()
+	require.NoError(t, err)
+	assert.Equal(t, token.AccessToken, loaded.AccessToken)
+	assert.True(t, token.Expiry.Equal(loaded.Expiry))
+
+	require.NoError(t, store.Delete())
+	require.NoError(t, store.Delete())
+
+	_, err = store.Load()
+	require.ErrorIs(t, err, ErrNoCredentials)
+}
+
+func TestKeyringStoreLoadErrors(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("fakes secret-tool")
+	}
+
+	testCases := []struct {
+		name   string
+		script string
+		err    error
+	}{
+		{
+			name:   "no entry",
+			script: "exit 1",
+			err:    ErrNoCredentials,
+		},
+		{
+			name:   "locked keyring",
+			script: "echo 'Cannot unlock the collection' >&2; exit 1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bin := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(bin, "secret-tool"), []byte("#!/bin/sh\n"+tc.script+"\n"), 0o755))
+			t.Setenv("PATH", bin)
+
+			_, err := NewKeyringStore("wakflo-test", "default").Load()
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+
+			require.ErrorContains(t, err, "Cannot unlock the collection")
+			assert.NotErrorIs(t, err, ErrNoCredentials)
+		})
+	}
+}
+
+func TestTokenEnvOverride(t *testing.T) {
+	a := newTestAuth(t, DefaultBaseURL)
+	require.NoError(t, a.SetToken(&Token{AccessToken: "from-store"}))
+
+	t.Setenv(TokenEnv, "from-env")
+
+	assert.Equal(t, "from-env", a.GetToken())
+}
+
+func TestTokenRefresh(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, tokenPath, r.URL.Path)
+		assert.Equal(t, refreshGrantType, r.PostForm.Get("grant_type"))
+		assert.Equal(t, "old-refresh", r.PostForm.Get("refresh_token"))
+
+		_ = json.NewEncoder(w).Encode(Token{AccessToken: "new-access", ExpiresIn: 3600})
+	}))
+	t.Cleanup(srv.Close)
+
+	a := newTestAuth(t, srv.URL)
+	require.NoError(t, a.SetToken(&Token{
+		AccessToken:  "old-access",
+		RefreshToken: "old-refresh",
+		Expiry:       time.Now().Add(-time.Minute),
+	}))
+
+	assert.Equal(t, "new-access", a.GetToken())
+
+	stored, err := a.Store.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "new-access", stored.AccessToken)
+	assert.Equal(t, "old-refresh", stored.RefreshToken)
+	assert.True(t, stored.Valid())
+}
+
+func TestExpiredTokenWithoutRefresh(t *testing.T) {
+	a := newTestAuth(t, DefaultBaseURL)
+	require.NoError(t, a.SetToken(&Token{AccessToken: "old", Expiry: time.Now().Add(-time.Minute)}))
+
+	assert.False(t, a.IsLoggedIn())
+
+	status, err := a.Status(context.Background())
+	require.NoError(t, err)
+	assert.False(t, status.LoggedIn)
+	assert.Contains(t, status.Error, "run 'wakflo auth login'")
+}
+
+func TestStatusWithRevokedRefreshToken(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error": "invalid_grant", "error_description": "refresh token revoked"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	a := newTestAuth(t, srv.URL)
+	require.NoError(t, a.SetToken(&Token{AccessToken: "old", RefreshToken: "revoked", Expiry: time.Now().Add(-time.Minute)}))
+
+	status, err := a.Status(context.Background())
+	require.NoError(t, err)
+	assert.False(t, status.LoggedIn)
+	assert.Contains(t, status.Error, "invalid_grant: refresh token revoked")
+	assert.Contains(t, status.Error, "run 'wakflo auth login'")
+}
@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+const refreshGrantType = "refresh_token"
+
+// expiryLeeway makes tokens count as expired slightly early so that a token is
+// never sent to the API in the last moments of its lifetime.
+const expiryLeeway = 30 * time.Second
+
+var ErrNoRefreshToken = errors.New("token expired and no refresh token is available, run 'wakflo auth login'")
+
+// Token is an OAuth 2.0 access token issued to the CLI.
+type Token struct {
+	AccessToken  string    `json:"access_token"`
+	TokenType    string    `json:"token_type,omitempty"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	ExpiresIn    int       `json:"expires_in,omitempty"`
+	Expiry       time.Time `json:"expiry,omitempty"`
+}
+
+// Valid reports whether the token carries an access token that has not expired yet.
+func (t *Token) Valid() bool {
+	if t == nil || t.AccessToken == "" {
+		return false
+	}
+
+	return !t.Expired()
+}
+
+// Expired reports whether the token has an expiry that has passed.
+func (t *Token) Expired() bool {
+	if t.Expiry.IsZero() {
+		return false
+	}
+
+	return time.Now().Add(expiryLeeway).After(t.Expiry)
+}
+
+func (t *Token) setExpiry() {
+	if t.ExpiresIn > 0 {
+		t.Expiry = time.Now().Add(time.Duration(t.ExpiresIn) * time.Second)
+	}
+}
+
+// Refresh exchanges the refresh token of t for a new access token.
+func (a *Auth) Refresh(ctx context.Context, t *Token) (*Token, error) {
+	if t == nil || t.RefreshToken == "" {
+		return nil, ErrNoRefreshToken
+	}
+
+	form := url.Values{
+		"grant_type":    {refreshGrantType},
+		"refresh_token": {t.RefreshToken},
+		"client_id":     {a.ClientID},
+	}
+
+	var token Token
+	if err := a.postForm(ctx, tokenPath, form, &token); err != nil {
+		return nil, fmt.Errorf("failed to refresh token: %w", err)
+	}
+
+	// servers may omit the refresh token when it is not rotated
+	if token.RefreshToken == "" {
+		token.RefreshToken = t.RefreshToken
+	}
+
+	token.setExpiry()
+
+	return &token, nil
+}
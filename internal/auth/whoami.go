@@ -0,0 +1,145 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const whoamiPath = "/auth/me"
+
+var ErrNotLoggedIn = errors.New("not logged in, run 'wakflo auth login'")
+
+type Account struct {
+	ID    string `json:"id"`
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+type Workspace struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+	Slug string `json:"slug,omitempty"`
+}
+
+// Identity describes the account a token belongs to.
+type Identity struct {
+	Account   Account    `json:"account"`
+	Workspace *Workspace `json:"workspace,omitempty"`
+}
+
+// Status summarises the current session.
+type Status struct {
+	LoggedIn    bool       `json:"logged_in"`
+	Profile     string     `json:"profile"`
+	Environment string     `json:"environment,omitempty"`
+	APIURL      string     `json:"api_url"`
+	TokenSource string     `json:"token_source,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	Account     *Account   `json:"account,omitempty"`
+	Workspace   *Workspace `json:"workspace,omitempty"`
+	Error       string     `json:"error,omitempty"`
+}
+
+// WhoAMI asks the Wakflo API who the current token belongs to.
+func (a *Auth) WhoAMI(ctx context.Context) (*Identity, error) {
+	token, err := a.Token(ctx)
+	if errors.Is(err, ErrNoCredentials) {
+		return nil, ErrNotLoggedIn
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(a.BaseURL, "/")+whoamiPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	req.Header.Set("Accept", "application/json")
+
+	if a.Workspace != "" {
+		req.Header.Set(WorkspaceHeader, a.Workspace)
+	}
+
+	resp, err := a.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch account: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, fmt.Errorf("token was rejected by %s: %w", a.BaseURL, ErrNotLoggedIn)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch account: unexpected response status %s", resp.Status)
+	}
+
+	var identity Identity
+	if err := json.NewDecoder(resp.Body).Decode(&identity); err != nil {
+		return nil, fmt.Errorf("failed to parse account: %w", err)
+	}
+
+	return &identity, nil
+}
+
+// Status reports whether a session exists and, when the API is reachable, who it belongs to.
+// Failing to load or refresh the session, or to reach the API, is reported in
+// Status.Error rather than returned.
+func (a *Auth) Status(ctx context.Context) (*Status, error) {
+	status := &Status{Profile: a.Profile, Environment: a.Environment, APIURL: a.BaseURL}
+
+	token, err := a.Token(ctx)
+	if errors.Is(err, ErrNoCredentials) {
+		return status, nil
+	}
+
+	// a session that cannot be loaded or refreshed is the same as none
+	switch {
+	case errors.Is(err, ErrNoRefreshToken):
+		status.Error = err.Error()
+		return status, nil
+	case err != nil:
+		status.Error = fmt.Sprintf("%v, run 'wakflo auth login'", err)
+		return status, nil
+	}
+
+	status.LoggedIn = true
+	status.TokenSource = a.tokenSource()
+
+	if !token.Expiry.IsZero() {
+		status.ExpiresAt = &token.Expiry
+	}
+
+	identity, err := a.WhoAMI(ctx)
+	if err != nil {
+		status.Error = err.Error()
+
+		return status, nil
+	}
+
+	status.Account = &identity.Account
+	status.Workspace = identity.Workspace
+
+	return status, nil
+}
+
+func (a *Auth) tokenSource() string {
+	if os.Getenv(TokenEnv) != "" {
+		return TokenEnv
+	}
+
+	if _, ok := a.Store.(*KeyringStore); ok {
+		return StoreKeyring
+	}
+
+	return StoreFile
+}
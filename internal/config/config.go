@@ -0,0 +1,229 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/BurntSushi/toml"
+)
+
+const (
+	// APIURLEnv overrides the API base URL from any other source.
+	APIURLEnv = "WAKFLO_API_URL"
+	// EnvEnv selects a named API environment, e.g. "local" or "staging".
+	EnvEnv = "WAKFLO_ENV"
+	// ProjectTemplatesEnv, when true, lets the project-level config select the
+	// template pack with templates.source, which is ignored otherwise.
+	ProjectTemplatesEnv = "WAKFLO_PROJECT_TEMPLATES"
+
+	// FileName is the user-level config file inside Dir.
+	FileName = "config.toml"
+	// ProjectFileName is the project-level config file, looked up from the
+	// working directory upwards. Its values override the user-level ones.
+	ProjectFileName = ".wakflo.toml"
+)
+
+var (
+	DefaultAuthors    = []string{"Wakflo <integrations@wakflo.com>"}
+	DefaultCategories = []string{"app"}
+	DefaultVersion    = "0.0.1"
+)
+
+// Config is the CLI configuration read from config.toml and .wakflo.toml.
+type Config struct {
+	API       API       `toml:"api" json:"api"`
+	Defaults  Defaults  `toml:"defaults" json:"defaults"`
+	Templates Templates `toml:"templates" json:"templates"`
+	Output    Output    `toml:"output" json:"output"`
+}
+
+// API selects the Wakflo API the CLI talks to. URL takes precedence over Env.
+// It is only read from the user-level config.
+type API struct {
+	URL string `toml:"url,omitempty" json:"url,omitempty"`
+	Env string `toml:"env,omitempty" json:"env,omitempty"`
+}
+
+// Defaults pre-fill the metadata of newly created integrations.
+type Defaults struct {
+	Authors    []string `toml:"authors,omitempty" json:"authors,omitempty"`
+	Categories []string `toml:"categories,omitempty" json:"categories,omitempty"`
+	Version    string   `toml:"version,omitempty" json:"version,omitempty"`
+}
+
+// Templates configures where scaffolding templates come from. Source is a
+// template pack directory, "git+<url>[#<ref>]" or the https URL of a .tar.gz
+// archive; the built-in templates are used when it is empty. A project-level
+// Source is only used when ProjectTemplatesEnv is set.
+type Templates struct {
+	Source string `toml:"source,omitempty" json:"source,omitempty"`
+}
+
+// Output configures how commands print their results.
+type Output struct {
+	Format string `toml:"format,omitempty" json:"format,omitempty"`
+}
+
+// AuthorsOrDefault returns the configured authors or DefaultAuthors.
+func (d Defaults) AuthorsOrDefault() []string {
+	if len(d.Authors) == 0 {
+		return DefaultAuthors
+	}
+
+	return d.Authors
+}
+
+// CategoriesOrDefault returns the configured categories or DefaultCategories.
+func (d Defaults) CategoriesOrDefault() []string {
+	if len(d.Categories) == 0 {
+		return DefaultCategories
+	}
+
+	return d.Categories
+}
+
+// VersionOrDefault returns the configured version or DefaultVersion.
+func (d Defaults) VersionOrDefault() string {
+	if d.Version == "" {
+		return DefaultVersion
+	}
+
+	return d.Version
+}
+
+// Dir returns the directory holding the CLI's user-level configuration and state.
+func Dir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate user config dir: %w", err)
+	}
+
+	return filepath.Join(dir, "wakflo"), nil
+}
+
+// CacheDir returns the directory holding the CLI's downloads, such as remote
+// template packs.
+func CacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate user cache dir: %w", err)
+	}
+
+	return filepath.Join(dir, "wakflo"), nil
+}
+
+// UserFile returns the path of the user-level config file.
+func UserFile() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, FileName), nil
+}
+
+// ProjectFile returns the path of the closest .wakflo.toml in dir or one of its
+// parents, or "" when there is none.
+func ProjectFile(dir string) string {
+	for {
+		path := filepath.Join(dir, ProjectFileName)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+
+		dir = parent
+	}
+}
+
+// Load reads the user-level config and merges the project-level config of the
+// working directory on top of it. Missing files are ignored. The api section
+// of the project is ignored too, and so is its templates.source unless
+// ProjectTemplatesEnv is set.
+func Load() (*Config, error) {
+	path, err := UserFile()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := LoadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// without a working directory there is no project config to merge
+	wd, err := os.Getwd()
+	if err != nil {
+		return cfg, nil //nolint: nilerr
+	}
+
+	if projectPath := ProjectFile(wd); projectPath != "" {
+		project, err := LoadFile(projectPath)
+		if err != nil {
+			return nil, err
+		}
+
+		// a cloned repository must not pick the API tokens are sent to, nor
+		// the templates generating code
+		project.API = API{}
+
+		if allowed, _ := strconv.ParseBool(os.Getenv(ProjectTemplatesEnv)); !allowed {
+			project.Templates.Source = ""
+		}
+
+		cfg.Merge(project)
+	}
+
+	return cfg, nil
+}
+
+// LoadFile reads the config at path. A missing file yields an empty config.
+func LoadFile(path string) (*Config, error) {
+	cfg := &Config{}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	if err := toml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse '%s': %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+// SaveFile writes cfg to path, creating parent directories as needed.
+func SaveFile(path string, cfg *Config) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create config dir: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(cfg); err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	return os.WriteFile(path, buf.Bytes(), 0o600)
+}
+
+// Merge copies every value set in other over c.
+func (c *Config) Merge(other *Config) {
+	for _, key := range Keys() {
+		if value := key.field(other); !value.IsZero() {
+			key.field(c).Set(value)
+		}
+	}
+}
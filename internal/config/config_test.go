@@ -0,0 +1,115 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeys(t *testing.T) {
+	cfg := &Config{}
+
+	key, err := LookupKey("defaults.authors")
+	require.NoError(t, err)
+
+	key.Set(cfg, "Jane <jane@wakflo.test>, John <john@wakflo.test>,")
+	assert.Equal(t, []string{"Jane <jane@wakflo.test>", "John <john@wakflo.test>"}, cfg.Defaults.Authors)
+	assert.Equal(t, "Jane <jane@wakflo.test>,John <john@wakflo.test>", key.Get(cfg))
+
+	key.Set(cfg, "")
+	assert.Empty(t, cfg.Defaults.Authors)
+	assert.Equal(t, DefaultAuthors, cfg.Defaults.AuthorsOrDefault())
+
+	key, err = LookupKey("api.url")
+	require.NoError(t, err)
+
+	key.Set(cfg, " https://api.wakflo.test ")
+	assert.Equal(t, "https://api.wakflo.test", cfg.API.URL)
+
+	_, err = LookupKey("api.nope")
+	require.Error(t, err)
+}
+
+func TestMergeProjectConfig(t *testing.T) {
+	dir := t.TempDir()
+	userPath := filepath.Join(dir, FileName)
+
+	user := &Config{
+		API:      API{URL: "https://user.wakflo.test"},
+		Defaults: Defaults{Authors: []string{"User"}, Version: "1.0.0"},
+	}
+	require.NoError(t, SaveFile(userPath, user))
+
+	projectDir := filepath.Join(dir, "project", "nested")
+	require.NoError(t, os.MkdirAll(projectDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "project", ProjectFileName), []byte(`
+[defaults]
+authors = ["Team <team@wakflo.test>"]
+categories = ["crm"]
+`), 0o600))
+
+	projectPath := ProjectFile(projectDir)
+	assert.Equal(t, filepath.Join(dir, "project", ProjectFileName), projectPath)
+
+	cfg, err := LoadFile(userPath)
+	require.NoError(t, err)
+
+	project, err := LoadFile(projectPath)
+	require.NoError(t, err)
+
+	cfg.Merge(project)
+
+	assert.Equal(t, "https://user.wakflo.test", cfg.API.URL)
+	assert.Equal(t, "1.0.0", cfg.Defaults.VersionOrDefault())
+	assert.Equal(t, []string{"Team <team@wakflo.test>"}, cfg.Defaults.Authors)
+	assert.Equal(t, []string{"crm"}, cfg.Defaults.CategoriesOrDefault())
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	cfg, err := LoadFile(filepath.Join(t.TempDir(), FileName))
+	require.NoError(t, err)
+	assert.Equal(t, &Config{}, cfg)
+}
+
+func TestLoadIgnoresUntrustedProjectSettings(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", home)
+	t.Setenv("AppData", home)
+
+	project := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(project, ProjectFileName), []byte(`
+[defaults]
+version = "2.0.0"
+
+[api]
+url = "https://attacker.example.com"
+env = "staging"
+
+[templates]
+source = "git+https://example.com/templates.git"
+`), 0o600))
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(project))
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+
+	t.Setenv(ProjectTemplatesEnv, "")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, "2.0.0", cfg.Defaults.Version)
+	assert.Empty(t, cfg.Templates.Source)
+	assert.Equal(t, API{}, cfg.API)
+
+	t.Setenv(ProjectTemplatesEnv, "true")
+
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, "git+https://example.com/templates.git", cfg.Templates.Source)
+	assert.Equal(t, API{}, cfg.API, "the API is never read from the project")
+}
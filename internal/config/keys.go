@@ -0,0 +1,95 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Key is a dotted config key such as "api.url" or "defaults.authors".
+type Key struct {
+	Name string
+
+	section int
+	index   int
+}
+
+// Keys lists every settable key in file order.
+func Keys() []Key {
+	var keys []Key
+
+	t := reflect.TypeOf(Config{})
+	for i := 0; i < t.NumField(); i++ {
+		section := t.Field(i)
+
+		for j := 0; j < section.Type.NumField(); j++ {
+			keys = append(keys, Key{
+				Name:    tomlName(section) + "." + tomlName(section.Type.Field(j)),
+				section: i,
+				index:   j,
+			})
+		}
+	}
+
+	return keys
+}
+
+// LookupKey returns the key called name.
+func LookupKey(name string) (Key, error) {
+	for _, key := range Keys() {
+		if key.Name == name {
+			return key, nil
+		}
+	}
+
+	names := make([]string, 0, len(Keys()))
+	for _, key := range Keys() {
+		names = append(names, key.Name)
+	}
+
+	return Key{}, fmt.Errorf("unknown config key '%s', expected one of: %s", name, strings.Join(names, ", "))
+}
+
+// UserOnly reports whether the key is ignored in project-level configs.
+func (k Key) UserOnly() bool {
+	return strings.HasPrefix(k.Name, "api.")
+}
+
+// Get returns the value of the key in cfg. Lists are joined with commas.
+func (k Key) Get(cfg *Config) string {
+	value := k.field(cfg)
+	if value.Kind() == reflect.Slice {
+		return strings.Join(value.Interface().([]string), ",")
+	}
+
+	return value.String()
+}
+
+// Set parses value into the key of cfg. Lists are split on commas and an empty
+// value clears the key.
+func (k Key) Set(cfg *Config, value string) {
+	field := k.field(cfg)
+	if field.Kind() != reflect.Slice {
+		field.SetString(strings.TrimSpace(value))
+		return
+	}
+
+	var items []string
+
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	field.Set(reflect.ValueOf(items))
+}
+
+func (k Key) field(cfg *Config) reflect.Value {
+	return reflect.ValueOf(cfg).Elem().Field(k.section).Field(k.index)
+}
+
+func tomlName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("toml"), ",")
+	return name
+}
@@ -0,0 +1,238 @@
+package runner
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/samber/lo"
+)
+
+// DefaultFixturesDir is where fixtures are kept in an integration project. The
+// go tool ignores testdata folders.
+const DefaultFixturesDir = "testdata"
+
+// Resource kinds of fixtures.
+const (
+	KindAction  = "action"
+	KindTrigger = "trigger"
+)
+
+// Fixture is a recorded execution of an action or trigger. Auth is not
+// recorded, replays use the one they are given.
+type Fixture struct {
+	Kind       string           `json:"kind"`
+	Name       string           `json:"name"`
+	RecordedAt time.Time        `json:"recorded_at"`
+	Input      json.RawMessage  `json:"input,omitempty"`
+	Metadata   *TriggerMetadata `json:"metadata,omitempty"`
+	Output     json.RawMessage  `json:"output,omitempty"`
+	Error      string           `json:"error,omitempty"`
+
+	// Path is the file the fixture was saved to or loaded from.
+	Path string `json:"-"`
+}
+
+// NewFixture returns the fixture of an execution of the resource called name.
+// Metadata is only kept for triggers.
+func NewFixture(kind, name string, event Event) *Fixture {
+	f := &Fixture{Kind: kind, Name: name, RecordedAt: event.Time, Input: event.Input, Output: event.Output}
+
+	if event.Err != nil {
+		f.Error = event.Err.Error()
+		f.Output = nil
+	}
+
+	if kind == KindTrigger {
+		metadata := event.Metadata
+		f.Metadata = &metadata
+	}
+
+	return f
+}
+
+// FixturesDir returns the folder of the fixtures of the resource of kind
+// called name, e.g. testdata/actions/send_message for the action "Send
+// Message", so that an action and a trigger of the same name do not share it.
+// The name is the one returned by Runner.ResolveName, so that any reference to
+// a resource uses its folder.
+func FixturesDir(root, kind, name string) string {
+	return filepath.Join(root, kind+"s", lo.SnakeCase(name))
+}
+
+// Save writes f in dir, named after the time it was recorded at.
+func (f *Fixture) Save(dir string) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	path := filepath.Join(dir, f.RecordedAt.UTC().Format("20060102-150405.000000")+".json")
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	f.Path = path
+
+	return file.Close()
+}
+
+// LoadFixtures reads the fixtures in dir, oldest first.
+func LoadFixtures(dir string) ([]*Fixture, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	slices.Sort(paths)
+
+	fixtures := make([]*Fixture, 0, len(paths))
+
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		f := &Fixture{Path: path}
+		if err := json.Unmarshal(data, f); err != nil {
+			return nil, fmt.Errorf("failed to read fixture '%s': %w", path, err)
+		}
+
+		fixtures = append(fixtures, f)
+	}
+
+	return fixtures, nil
+}
+
+// Replay is the result of replaying a fixture.
+type Replay struct {
+	Fixture *Fixture
+	Output  json.RawMessage
+	Err     error
+
+	// Diff is a unified diff from the recorded to the replayed output, empty
+	// when they match.
+	Diff string
+}
+
+// ReplayFixtures runs the resource of kind called name again with the input
+// and metadata of every fixture in dir, and compares its output with the
+// recorded one. Triggers are started before and stopped after.
+func (r *Runner) ReplayFixtures(kind, name, dir string, auth json.RawMessage) (replays []Replay, err error) {
+	fixtures, err := LoadFixtures(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(fixtures) == 0 {
+		return nil, fmt.Errorf("no fixtures in '%s', record some with --record: %w", dir, os.ErrNotExist)
+	}
+
+	for _, f := range fixtures {
+		if f.Kind != kind {
+			return nil, fmt.Errorf("fixture '%s' is of a %s, not of a %s", f.Path, f.Kind, kind)
+		}
+	}
+
+	if kind == KindTrigger {
+		// the trigger is started with the properties it was recorded with
+		properties := fixtures[0].Input
+
+		if err := r.StartTrigger(name, properties, auth); err != nil {
+			return nil, fmt.Errorf("failed to start trigger '%s': %w", name, err)
+		}
+
+		defer func() {
+			if stopErr := r.StopTrigger(name, properties, auth); stopErr != nil {
+				err = errors.Join(err, fmt.Errorf("failed to stop trigger '%s': %w", name, stopErr))
+			}
+		}()
+	}
+
+	for _, f := range fixtures {
+		replay := Replay{Fixture: f}
+
+		if kind == KindAction {
+			replay.Output, replay.Err = r.Perform(name, f.Input, auth)
+		} else {
+			replay.Output, replay.Err = r.ExecuteTrigger(name, f.Input, auth, lo.FromPtr(f.Metadata))
+		}
+
+		if replay.Diff, err = diffReplay(f, replay); err != nil {
+			return nil, fmt.Errorf("failed to compare with fixture '%s': %w", f.Path, err)
+		}
+
+		replays = append(replays, replay)
+	}
+
+	return replays, nil
+}
+
+// diffReplay compares replay with what was recorded in f, JSON objects being
+// equal whatever the order of their keys.
+func diffReplay(f *Fixture, replay Replay) (string, error) {
+	expected, err := replayText(f.Output, f.Error)
+	if err != nil {
+		return "", err
+	}
+
+	errText := ""
+	if replay.Err != nil {
+		errText = replay.Err.Error()
+	}
+
+	actual, err := replayText(replay.Output, errText)
+	if err != nil {
+		return "", err
+	}
+
+	if expected == actual {
+		return "", nil
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(expected),
+		B:        difflib.SplitLines(actual),
+		FromFile: "recorded",
+		ToFile:   "replayed",
+		Context:  3,
+	})
+}
+
+// replayText renders an output, or an error, in a canonical form.
+func replayText(output json.RawMessage, errText string) (string, error) {
+	if errText != "" {
+		return "error: " + errText, nil
+	}
+
+	var v any
+	if len(output) > 0 {
+		if err := json.Unmarshal(output, &v); err != nil {
+			return "", err
+		}
+	}
+
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
@@ -0,0 +1,92 @@
+package runner
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixtureSaveLoad(t *testing.T) {
+	dir := FixturesDir(t.TempDir(), KindTrigger, "New Message")
+	assert.Equal(t, "new_message", filepath.Base(dir))
+	assert.Equal(t, "triggers", filepath.Base(filepath.Dir(dir)))
+
+	lastRun := time.Date(2024, time.January, 17, 10, 0, 0, 0, time.UTC)
+	first := NewFixture(KindTrigger, "New Message", Event{
+		Time:     lastRun.Add(time.Minute),
+		Input:    json.RawMessage(`{"name":"Ada"}`),
+		Metadata: TriggerMetadata{LastRun: &lastRun},
+		Output:   json.RawMessage(`{"message":"Triggered by Ada!"}`),
+	})
+	second := NewFixture(KindTrigger, "New Message", Event{
+		Time: lastRun.Add(2 * time.Minute),
+		Err:  errors.New("rate limited"),
+	})
+
+	// saved out of order
+	require.NoError(t, second.Save(dir))
+	require.NoError(t, first.Save(dir))
+	require.ErrorIs(t, first.Save(dir), os.ErrExist)
+
+	fixtures, err := LoadFixtures(dir)
+	require.NoError(t, err)
+	require.Len(t, fixtures, 2)
+	assert.Equal(t, first.Path, fixtures[0].Path)
+	assert.Equal(t, KindTrigger, fixtures[0].Kind)
+	assert.Equal(t, "New Message", fixtures[0].Name)
+	assert.True(t, first.RecordedAt.Equal(fixtures[0].RecordedAt))
+	assert.JSONEq(t, `{"name": "Ada"}`, string(fixtures[0].Input))
+	assert.JSONEq(t, `{"message": "Triggered by Ada!"}`, string(fixtures[0].Output))
+	require.NotNil(t, fixtures[0].Metadata)
+	assert.True(t, lastRun.Equal(*fixtures[0].Metadata.LastRun))
+
+	assert.Equal(t, second.Path, fixtures[1].Path)
+	assert.Equal(t, "rate limited", fixtures[1].Error)
+	assert.Nil(t, fixtures[1].Output)
+}
+
+func TestDiffReplay(t *testing.T) {
+	testCases := []struct {
+		name     string
+		recorded *Fixture
+		replay   Replay
+		diff     string
+	}{
+		{
+			name:     "same output",
+			recorded: &Fixture{Output: json.RawMessage(`{"a": 1, "b": [true]}`)},
+			replay:   Replay{Output: json.RawMessage(`{"b":[true],"a":1}`)},
+		},
+		{
+			name:     "same error",
+			recorded: &Fixture{Error: "not found"},
+			replay:   Replay{Err: errors.New("not found")},
+		},
+		{
+			name:     "changed output",
+			recorded: &Fixture{Output: json.RawMessage(`{"a": 1}`)},
+			replay:   Replay{Output: json.RawMessage(`{"a": 2}`)},
+			diff:     "--- recorded\n+++ replayed\n@@ -1,3 +1,3 @@\n {\n-  \"a\": 1\n+  \"a\": 2\n }\n",
+		},
+		{
+			name:     "new error",
+			recorded: &Fixture{Output: json.RawMessage(`null`)},
+			replay:   Replay{Err: errors.New("not found")},
+			diff:     "--- recorded\n+++ replayed\n@@ -1 +1 @@\n-null\n+error: not found\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			diff, err := diffReplay(tc.recorded, tc.replay)
+			require.NoError(t, err)
+			assert.Equal(t, tc.diff, diff)
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package runner
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+const sdkImportPath = "github.com/wakflo/go-sdk/sdk"
+
+//go:embed harness.go.tmpl
+var harnessTemplate string
+
+// harness is what the generated main package needs to know about the integration.
+type harness struct {
+	ImportPath  string
+	Integration string
+}
+
+// newHarness inspects the integration package in dir.
+func newHarness(dir string) (*harness, error) {
+	integration, err := registeredIntegration(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	list := exec.Command("go", "list", "-f", "{{ .ImportPath }}", ".")
+	list.Dir = dir
+
+	out, err := list.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("failed to resolve the import path of '%s': %s", dir, bytes.TrimSpace(exitErr.Stderr))
+		}
+
+		return nil, fmt.Errorf("failed to resolve the import path of '%s': %w", dir, err)
+	}
+
+	return &harness{ImportPath: strings.TrimSpace(string(out)), Integration: integration}, nil
+}
+
+// render returns the source of the harness main package.
+func (h *harness) render() ([]byte, error) {
+	var buf bytes.Buffer
+
+	if err := template.Must(template.New("harness").Parse(harnessTemplate)).Execute(&buf, h); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// registeredIntegration returns the expression passed to sdk.Register in the
+// package in dir, qualified to be used from another package.
+func registeredIntegration(dir string) (string, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return "", err
+	}
+
+	fset := token.NewFileSet()
+
+	var found []ast.Expr
+
+	for _, match := range matches {
+		if strings.HasSuffix(match, "_test.go") {
+			continue
+		}
+
+		src, err := os.ReadFile(match)
+		if err != nil {
+			return "", err
+		}
+
+		file, err := parser.ParseFile(fset, match, src, 0)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse '%s': %w", match, err)
+		}
+
+		sdkName := importName(file, sdkImportPath)
+		if sdkName == "" {
+			continue
+		}
+
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) == 0 {
+				return true
+			}
+
+			if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "Register" {
+				if pkg, ok := sel.X.(*ast.Ident); ok && pkg.Name == sdkName {
+					found = append(found, call.Args[0])
+				}
+			}
+
+			return true
+		})
+	}
+
+	if len(found) != 1 {
+		return "", fmt.Errorf("expected exactly one sdk.Register() call in '%s', found %d", dir, len(found))
+	}
+
+	return qualify(found[0])
+}
+
+// importName returns the name path is imported as in file, or "" when it is not.
+func importName(file *ast.File, path string) string {
+	for _, spec := range file.Imports {
+		if importPath, _ := strconv.Unquote(spec.Path.Value); importPath != path {
+			continue
+		}
+
+		if spec.Name != nil {
+			return spec.Name.Name
+		}
+
+		return filepath.Base(path)
+	}
+
+	return ""
+}
+
+// qualify renders the expressions integrations are usually registered with,
+// NewX(), &X{} or X, with their identifiers taken from the integration package.
+func qualify(expr ast.Expr) (string, error) {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if !ast.IsExported(e.Name) {
+			return "", fmt.Errorf("the registered integration '%s' is not exported", e.Name)
+		}
+
+		return "integration." + e.Name, nil
+	case *ast.CallExpr:
+		if len(e.Args) == 0 {
+			fun, err := qualify(e.Fun)
+			return fun + "()", err
+		}
+	case *ast.UnaryExpr:
+		if lit, ok := e.X.(*ast.CompositeLit); ok && e.Op == token.AND && len(lit.Elts) == 0 {
+			typ, err := qualify(lit.Type)
+			return "&" + typ + "{}", err
+		}
+	}
+
+	return "", errors.New("the integration passed to sdk.Register() must be a variable, a constructor without arguments or an empty struct")
+}
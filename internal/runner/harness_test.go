@@ -0,0 +1,99 @@
+package runner
+
+import (
+	"go/parser"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQualify(t *testing.T) {
+	testCases := []struct {
+		expr     string
+		expected string
+		err      string
+	}{
+		{expr: "NewSlack()", expected: "integration.NewSlack()"},
+		{expr: "&Slack{}", expected: "&integration.Slack{}"},
+		{expr: "Slack", expected: "integration.Slack"},
+		{expr: "slack", err: "is not exported"},
+		{expr: "NewSlack(token)", err: "must be a variable"},
+		{expr: "&Slack{Token: token}", err: "must be a variable"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			expr, err := parser.ParseExpr(tc.expr)
+			require.NoError(t, err)
+
+			actual, err := qualify(expr)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestRegisteredIntegration(t *testing.T) {
+	testCases := []struct {
+		name     string
+		files    map[string]string
+		expected string
+		err      string
+	}{
+		{
+			name: "constructor",
+			files: map[string]string{
+				"lib.go": "package slack\n\nimport \"github.com/wakflo/go-sdk/sdk\"\n\nvar Integration = sdk.Register(NewSlack(), Flow, ReadME)\n",
+			},
+			expected: "integration.NewSlack()",
+		},
+		{
+			name: "renamed import",
+			files: map[string]string{
+				"lib.go": "package slack\n\nimport wakflo \"github.com/wakflo/go-sdk/sdk\"\n\nvar Integration = wakflo.Register(&Slack{}, \"\", \"\")\n",
+			},
+			expected: "&integration.Slack{}",
+		},
+		{
+			name: "test files are ignored",
+			files: map[string]string{
+				"lib.go":      "package slack\n\nimport \"github.com/wakflo/go-sdk/sdk\"\n\nvar Integration = sdk.Register(NewSlack(), Flow, ReadME)\n",
+				"lib_test.go": "package slack\n\nimport \"github.com/wakflo/go-sdk/sdk\"\n\nvar _ = sdk.Register(NewSlack(), \"\", \"\")\n",
+			},
+			expected: "integration.NewSlack()",
+		},
+		{
+			name: "not registered",
+			files: map[string]string{
+				"lib.go": "package slack\n\nfunc Register() {}\n",
+			},
+			err: "found 0",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tc.files {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+			}
+
+			actual, err := registeredIntegration(dir)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,211 @@
+// Package runner runs the actions and triggers of an integration project
+// locally. It builds a harness program next to the integration package and
+// talks to it with JSON messages over its standard input and output.
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+)
+
+// harnessDir prefixes the temporary folder holding the harness. The go tool
+// ignores folders starting with a dot in patterns such as ./...
+const harnessDir = ".wakflo-run-"
+
+// Runner is a running harness of an integration project.
+type Runner struct {
+	dir   string
+	cmd   *exec.Cmd
+	stdin io.Closer
+
+	// waited makes the harness waited for once, by a failing call or Close
+	waited  sync.Once
+	waitErr error
+
+	// mu serializes calls, the harness answers one request at a time
+	mu        sync.Mutex
+	requests  *json.Encoder
+	responses *json.Decoder
+}
+
+type request struct {
+	Op    string          `json:"op"`
+	Kind  string          `json:"kind,omitempty"`
+	Name  string          `json:"name"`
+	Input json.RawMessage `json:"input,omitempty"`
+	Auth  json.RawMessage `json:"auth,omitempty"`
+
+	Metadata any `json:"metadata,omitempty"`
+}
+
+type response struct {
+	Output json.RawMessage `json:"output,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// Start builds the harness of the integration project in dir and starts it.
+// Build errors and whatever the resources log go to stderr. Cancelling ctx
+// stops the build, the harness itself runs until Close so that triggers can
+// still be stopped.
+func Start(ctx context.Context, dir string, stderr io.Writer) (*Runner, error) {
+	h, err := newHarness(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := h.render()
+	if err != nil {
+		return nil, fmt.Errorf("failed to render the harness: %w", err)
+	}
+
+	tmp, err := os.MkdirTemp(dir, harnessDir)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Runner{dir: tmp}
+
+	if err := r.start(ctx, dir, source, stderr); err != nil {
+		_ = os.RemoveAll(tmp)
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *Runner) start(ctx context.Context, dir string, source []byte, stderr io.Writer) error {
+	if err := os.WriteFile(filepath.Join(r.dir, "main.go"), source, 0o644); err != nil {
+		return err
+	}
+
+	binary := filepath.Join(r.dir, "harness")
+
+	build := exec.CommandContext(ctx, "go", "build", "-o", binary, "./"+filepath.Base(r.dir))
+	build.Dir = dir
+	build.Stdout = stderr
+	build.Stderr = stderr
+
+	if err := build.Run(); err != nil {
+		return fmt.Errorf("failed to build the integration: %w", err)
+	}
+
+	r.cmd = exec.Command(binary)
+	r.cmd.Dir = dir
+	r.cmd.Stderr = stderr
+
+	stdin, err := r.cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+
+	stdout, err := r.cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+
+	if err := r.cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start the harness: %w", err)
+	}
+
+	r.stdin = stdin
+	r.requests = json.NewEncoder(stdin)
+	r.responses = json.NewDecoder(stdout)
+
+	return nil
+}
+
+// Perform runs the Perform method of the action called name, as in its Name()
+// or file name, with input and auth given as JSON objects.
+func (r *Runner) Perform(name string, input, auth json.RawMessage) (json.RawMessage, error) {
+	return r.call(request{Op: "perform", Name: name, Input: input, Auth: auth})
+}
+
+// ResolveName returns the Name() of the resource of kind referenced by name,
+// as in its name or file name. Fixtures are kept under the resolved name.
+func (r *Runner) ResolveName(kind, name string) (string, error) {
+	output, err := r.call(request{Op: "resolve", Kind: kind, Name: name})
+	if err != nil {
+		return "", err
+	}
+
+	var resolved string
+	if err := json.Unmarshal(output, &resolved); err != nil {
+		return "", fmt.Errorf("invalid resource name: %w", err)
+	}
+
+	return resolved, nil
+}
+
+func (r *Runner) call(req request) (json.RawMessage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.requests.Encode(req); err != nil {
+		return nil, r.exited(err)
+	}
+
+	var resp response
+	if err := r.responses.Decode(&resp); err != nil {
+		return nil, r.exited(err)
+	}
+
+	if resp.Error != "" {
+		return nil, errors.New(resp.Error)
+	}
+
+	return resp.Output, nil
+}
+
+// wait waits for the harness to exit and returns how it did.
+func (r *Runner) wait() error {
+	r.waited.Do(func() {
+		r.waitErr = r.cmd.Wait()
+	})
+
+	return r.waitErr
+}
+
+// exited reports why the harness stopped answering.
+func (r *Runner) exited(err error) error {
+	if waitErr := r.wait(); waitErr != nil {
+		return fmt.Errorf("the harness exited: %w", waitErr)
+	}
+
+	return fmt.Errorf("the harness exited: %w", err)
+}
+
+// Kill stops the harness at once, failing the call in progress. Close must
+// still be called to remove it.
+func (r *Runner) Kill() error {
+	if err := r.cmd.Process.Kill(); err != nil && !errors.Is(err, os.ErrProcessDone) {
+		return err
+	}
+
+	return nil
+}
+
+// Close stops the harness and removes it.
+func (r *Runner) Close() error {
+	defer os.RemoveAll(r.dir)
+
+	// stdin is closed already when the harness has been waited for
+	if err := r.stdin.Close(); err != nil && !errors.Is(err, os.ErrClosed) {
+		return err
+	}
+
+	if err := r.wait(); err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,301 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wakflo/go-sdk/sdk"
+	"github.com/wakflo/wakflo-cli/internal/templates"
+	"github.com/wakflo/wakflo-cli/internal/testutil"
+)
+
+// newTestIntegration scaffolds an integration with a "Send Message" action and
+// a "New Message" polling trigger against the go-sdk this module uses and returns its folder.
+func newTestIntegration(t *testing.T) string {
+	t.Helper()
+
+	root := testutil.GoModule(t)
+
+	require.NoError(t, templates.CreateIntegrationFolder(&templates.CreateIntegrationProps{
+		IntegrationSchemaModel: sdk.IntegrationSchemaModel{Name: "Slack", Description: "Slack integration", Version: "0.0.1"},
+	}, &templates.Project{Root: root, Out: io.Discard}))
+
+	dir := filepath.Join(root, "slack")
+	p := &templates.Project{Root: dir, Out: io.Discard}
+	gen := templates.NewOfflineGenerator()
+	require.NoError(t, templates.HandleAddResource(context.Background(), "action", &templates.AddResourceInput{Name: "Send Message", Description: "Sends"}, gen, p))
+	require.NoError(t, templates.HandleAddResource(context.Background(), "trigger", &templates.AddResourceInput{Name: "New Message", Description: "New", Type: "polling"}, gen, p))
+
+	return dir
+}
+
+func TestRunnerPerform(t *testing.T) {
+	dir := newTestIntegration(t)
+
+	r, err := Start(context.Background(), dir, io.Discard)
+	require.NoError(t, err)
+
+	output, err := r.Perform("send_message", json.RawMessage(`{"name": "Ada"}`), nil)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"message": "Hello Ada!"}`, string(output))
+
+	// actions are found by their name too
+	output, err = r.Perform("Send Message", json.RawMessage(`{"name": "Grace"}`), json.RawMessage(`{"access_token": "token"}`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"message": "Hello Grace!"}`, string(output))
+
+	_, err = r.Perform("archive", nil, nil)
+	require.ErrorContains(t, err, "unknown action 'archive', expected one of: Send Message")
+
+	_, err = r.Perform("send_message", json.RawMessage(`[]`), nil)
+	require.ErrorContains(t, err, "invalid input")
+
+	// every reference resolves to the name fixtures are kept under
+	for _, ref := range []string{"sendmessage", "send-message", "Send Message"} {
+		name, err := r.ResolveName(KindAction, ref)
+		require.NoError(t, err)
+		assert.Equal(t, "Send Message", name)
+		assert.Equal(t, filepath.Join("testdata", "actions", "send_message"), FixturesDir("testdata", KindAction, name))
+	}
+
+	name, err := r.ResolveName(KindTrigger, "new_message")
+	require.NoError(t, err)
+	assert.Equal(t, "New Message", name)
+
+	_, err = r.ResolveName(KindTrigger, "send_message")
+	require.ErrorContains(t, err, "unknown trigger 'send_message'")
+
+	require.NoError(t, r.Close())
+
+	// the harness is removed
+	matches, err := filepath.Glob(filepath.Join(dir, harnessDir+"*"))
+	require.NoError(t, err)
+	assert.Empty(t, matches)
+}
+
+func TestRunnerKill(t *testing.T) {
+	dir := newTestIntegration(t)
+
+	r, err := Start(context.Background(), dir, io.Discard)
+	require.NoError(t, err)
+
+	require.NoError(t, r.Kill())
+
+	_, err = r.Perform("send_message", nil, nil)
+	require.ErrorContains(t, err, "the harness exited")
+
+	// the harness is only waited for once
+	require.NoError(t, r.Close())
+}
+
+func TestRunnerRunTrigger(t *testing.T) {
+	dir := newTestIntegration(t)
+
+	// the trigger returns the last run it is given
+	path := filepath.Join(dir, "triggers", "new_message.go")
+	source, err := os.ReadFile(path)
+	require.NoError(t, err)
+	source = bytes.Replace(source, []byte("out := map[string]any{"), []byte("out := map[string]any{\n\t\t\"last_run\": ctx.Metadata.LastRun,"), 1)
+	require.NoError(t, os.WriteFile(path, source, 0o644))
+
+	r, err := Start(context.Background(), dir, io.Discard)
+	require.NoError(t, err)
+	defer r.Close()
+
+	info, err := r.DescribeTrigger("new_message")
+	require.NoError(t, err)
+	assert.Equal(t, &TriggerInfo{Name: "New Message", Type: TriggerTypePolling}, info)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var events []Event
+
+	err = r.RunTrigger(ctx, "new_message", TriggerOptions{Input: json.RawMessage(`{"name": "Ada"}`), Interval: 10 * time.Millisecond}, func(event Event) {
+		events = append(events, event)
+		if len(events) == 3 {
+			cancel()
+		}
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+
+	for i, event := range events {
+		require.NoError(t, event.Err)
+		assert.Equal(t, event.Time.Add(10*time.Millisecond), event.Next)
+
+		var output struct {
+			Message string     `json:"message"`
+			LastRun *time.Time `json:"last_run"`
+		}
+		require.NoError(t, json.Unmarshal(event.Output, &output))
+		assert.Equal(t, "Triggered by Ada!", output.Message)
+
+		if i == 0 {
+			assert.Nil(t, output.LastRun)
+		} else {
+			require.NotNil(t, output.LastRun)
+			assert.True(t, events[i-1].Time.Equal(*output.LastRun))
+		}
+	}
+
+	_, err = r.DescribeTrigger("archived")
+	require.ErrorContains(t, err, "unknown trigger 'archived', expected one of: New Message")
+}
+
+func TestRunnerServeWebhook(t *testing.T) {
+	dir := newTestIntegration(t)
+
+	r, err := Start(context.Background(), dir, io.Discard)
+	require.NoError(t, err)
+	defer r.Close()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := make(chan Event, 1)
+	served := make(chan error, 1)
+
+	go func() {
+		served <- r.ServeWebhook(ctx, l, "new_message", TriggerOptions{Input: json.RawMessage(`{"name": "Ada"}`)}, func(event Event, req *WebhookRequest) {
+			assert.Equal(t, "/hooks", req.Path)
+			events <- event
+		})
+	}()
+
+	resp, err := http.Post("http://"+l.Addr().String()+"/hooks", "application/json", strings.NewReader(`{"text": "hi"}`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.JSONEq(t, `{"message": "Triggered by Ada!"}`, string(body))
+
+	event := <-events
+	require.NoError(t, event.Err)
+	assert.Contains(t, string(event.Input), `"body":{"text":"hi"}`)
+
+	cancel()
+	require.NoError(t, <-served)
+}
+
+func TestRunnerReplayFixtures(t *testing.T) {
+	dir := newTestIntegration(t)
+	fixtures := FixturesDir(filepath.Join(dir, DefaultFixturesDir), KindAction, "send_message")
+
+	r, err := Start(context.Background(), dir, io.Discard)
+	require.NoError(t, err)
+	defer r.Close()
+
+	_, err = r.ReplayFixtures(KindAction, "send_message", fixtures, nil)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	for _, name := range []string{"Ada", "Grace"} {
+		event := Event{Time: time.Now(), Input: json.RawMessage(`{"name": "` + name + `"}`)}
+		event.Output, event.Err = r.Perform("send_message", event.Input, nil)
+		require.NoError(t, NewFixture(KindAction, "send_message", event).Save(fixtures))
+	}
+
+	replays, err := r.ReplayFixtures(KindAction, "send_message", fixtures, nil)
+	require.NoError(t, err)
+	require.Len(t, replays, 2)
+
+	for _, replay := range replays {
+		assert.Empty(t, replay.Diff)
+	}
+
+	// the action changed since the first fixture was recorded
+	recorded, err := LoadFixtures(fixtures)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(recorded[0].Path, bytes.Replace([]byte(mustMarshal(t, recorded[0])), []byte("Hello Ada!"), []byte("Hi Ada!"), 1), 0o644))
+
+	replays, err = r.ReplayFixtures(KindAction, "send_message", fixtures, nil)
+	require.NoError(t, err)
+	assert.Contains(t, replays[0].Diff, "-  \"message\": \"Hi Ada!\"\n+  \"message\": \"Hello Ada!\"")
+	assert.Empty(t, replays[1].Diff)
+
+	_, err = r.ReplayFixtures(KindTrigger, "send_message", fixtures, nil)
+	require.ErrorContains(t, err, "is of a action, not of a trigger")
+}
+
+func TestRunnerVerifySamples(t *testing.T) {
+	dir := newTestIntegration(t)
+	root := filepath.Join(dir, DefaultFixturesDir)
+
+	// the action returns more than its sample data shows
+	path := filepath.Join(dir, "actions", "send_message.go")
+	source, err := os.ReadFile(path)
+	require.NoError(t, err)
+	source = bytes.Replace(source, []byte("out := map[string]any{"), []byte("out := map[string]any{\n\t\t\"count\": 1,"), 1)
+	require.NoError(t, os.WriteFile(path, source, 0o644))
+
+	r, err := Start(context.Background(), dir, io.Discard)
+	require.NoError(t, err)
+	defer r.Close()
+
+	resources, err := r.Resources()
+	require.NoError(t, err)
+	require.Len(t, resources, 2)
+	assert.Equal(t, "Send Message", resources[0].Name)
+	assert.JSONEq(t, `{"message": "Hello World!"}`, string(resources[0].Sample))
+
+	event := Event{Time: time.Now(), Input: json.RawMessage(`{"name": "Ada"}`)}
+	event.Output, event.Err = r.Perform("send_message", event.Input, nil)
+	require.NoError(t, NewFixture(KindAction, "Send Message", event).Save(FixturesDir(root, KindAction, "Send Message")))
+
+	reports, err := r.VerifySamples(root, nil)
+	require.NoError(t, err)
+	require.Len(t, reports, 2)
+
+	assert.Equal(t, KindAction, reports[0].Kind)
+	assert.Equal(t, 1, reports[0].Fixtures)
+	assert.Equal(t, []Mismatch{{Path: "$.count", Output: "number"}}, reports[0].Mismatches)
+	assert.False(t, reports[0].OK())
+
+	assert.Equal(t, SampleReport{Kind: KindTrigger, Name: "New Message"}, reports[1])
+
+	// a run recorded failing passes when it fails the same way
+	failed := Event{Time: time.Now(), Input: json.RawMessage(`[]`)}
+	failed.Output, failed.Err = r.Perform("send_message", failed.Input, nil)
+	require.Error(t, failed.Err)
+	require.NoError(t, NewFixture(KindAction, "Send Message", failed).Save(FixturesDir(root, KindAction, "Send Message")))
+
+	// a fixture that cannot be replayed fails its resource only
+	require.NoError(t, NewFixture(KindAction, "New Message", event).Save(FixturesDir(root, KindTrigger, "New Message")))
+
+	reports, err = r.VerifySamples(root, nil)
+	require.NoError(t, err)
+	require.Len(t, reports, 2)
+
+	assert.Equal(t, 2, reports[0].Fixtures)
+	assert.Equal(t, []Mismatch{{Path: "$.count", Output: "number"}}, reports[0].Mismatches)
+	assert.Empty(t, reports[0].Errors)
+	assert.Equal(t, 1, reports[1].Fixtures)
+	require.Len(t, reports[1].Errors, 1)
+	assert.Contains(t, reports[1].Errors[0], "is of a action, not of a trigger")
+	assert.False(t, reports[1].OK())
+}
+
+func mustMarshal(t *testing.T, v any) string {
+	t.Helper()
+
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+
+	return string(data)
+}
@@ -0,0 +1,190 @@
+package runner
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// schedule returns the next time a trigger runs after t.
+type schedule interface {
+	Next(t time.Time) time.Time
+}
+
+// every runs at a fixed interval.
+type every time.Duration
+
+func (e every) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(e))
+}
+
+// cronSchedule is a standard five field cron expression, each field being the
+// set of values it matches.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+
+	// domStar or dowStar is set when the field is '*', in which case only the
+	// other one restricts the day, as in cron.
+	domStar, dowStar bool
+}
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// parseSchedule parses a cron expression such as "*/5 * * * *", a descriptor
+// such as "@hourly", or "@every <duration>".
+func parseSchedule(spec string) (schedule, error) {
+	spec = strings.TrimSpace(spec)
+
+	if interval, ok := strings.CutPrefix(spec, "@every "); ok {
+		d, err := time.ParseDuration(strings.TrimSpace(interval))
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule '%s': %w", spec, err)
+		}
+
+		if d <= 0 {
+			return nil, fmt.Errorf("invalid schedule '%s': the interval must be positive", spec)
+		}
+
+		return every(d), nil
+	}
+
+	expr := spec
+	if descriptor, ok := descriptors[spec]; ok {
+		expr = descriptor
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid schedule '%s': expected 5 fields, got %d", spec, len(fields))
+	}
+
+	var (
+		s   cronSchedule
+		err error
+	)
+
+	bounds := []struct {
+		set      *uint64
+		min, max int
+	}{
+		{&s.minute, 0, 59},
+		{&s.hour, 0, 23},
+		{&s.dom, 1, 31},
+		{&s.month, 1, 12},
+		{&s.dow, 0, 7},
+	}
+
+	for i, b := range bounds {
+		if *b.set, err = parseField(fields[i], b.min, b.max); err != nil {
+			return nil, fmt.Errorf("invalid schedule '%s': %w", spec, err)
+		}
+	}
+
+	// Sunday is 0 or 7
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+
+	s.domStar = fields[2] == "*"
+	s.dowStar = fields[4] == "*"
+
+	return &s, nil
+}
+
+// parseField parses a comma separated list of '*', values, ranges and steps.
+func parseField(field string, min, max int) (uint64, error) {
+	var set uint64
+
+	for _, part := range strings.Split(field, ",") {
+		rng, step, hasStep := strings.Cut(part, "/")
+
+		lo, hi := min, max
+
+		if rng != "*" {
+			from, to, isRange := strings.Cut(rng, "-")
+
+			var err error
+			if lo, err = parseValue(from, min, max); err != nil {
+				return 0, err
+			}
+
+			hi = lo
+			if isRange {
+				if hi, err = parseValue(to, lo, max); err != nil {
+					return 0, err
+				}
+			} else if hasStep {
+				hi = max
+			}
+		}
+
+		inc := 1
+		if hasStep {
+			n, err := strconv.Atoi(step)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step '%s'", step)
+			}
+
+			inc = n
+		}
+
+		for v := lo; v <= hi; v += inc {
+			set |= 1 << v
+		}
+	}
+
+	return set, nil
+}
+
+func parseValue(s string, min, max int) (int, error) {
+	v, err := strconv.Atoi(s)
+	if err != nil || v < min || v > max {
+		return 0, fmt.Errorf("invalid value '%s', expected %d to %d", s, min, max)
+	}
+
+	return v, nil
+}
+
+func (s *cronSchedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+
+	// a matching time is at most a few years away, e.g. for February 29
+	end := t.AddDate(5, 0, 0)
+
+	for t.Before(end) {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !s.matchDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+func (s *cronSchedule) matchDay(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+
+	if s.domStar || s.dowStar {
+		return dom && dow
+	}
+
+	return dom || dow
+}
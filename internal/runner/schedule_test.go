@@ -0,0 +1,67 @@
+package runner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSchedule(t *testing.T) {
+	// a Wednesday
+	now := time.Date(2024, time.January, 17, 10, 7, 30, 0, time.UTC)
+
+	testCases := []struct {
+		spec     string
+		expected time.Time
+		err      string
+	}{
+		{spec: "* * * * *", expected: time.Date(2024, time.January, 17, 10, 8, 0, 0, time.UTC)},
+		{spec: "*/15 * * * *", expected: time.Date(2024, time.January, 17, 10, 15, 0, 0, time.UTC)},
+		{spec: "0 9-17/4 * * *", expected: time.Date(2024, time.January, 17, 13, 0, 0, 0, time.UTC)},
+		{spec: "30 8 * * 1,5", expected: time.Date(2024, time.January, 19, 8, 30, 0, 0, time.UTC)},
+		{spec: "0 0 * * 7", expected: time.Date(2024, time.January, 21, 0, 0, 0, 0, time.UTC)},
+		{spec: "0 0 1 * 1", expected: time.Date(2024, time.January, 22, 0, 0, 0, 0, time.UTC)},
+		{spec: "0 0 29 2 *", expected: time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{spec: "@hourly", expected: time.Date(2024, time.January, 17, 11, 0, 0, 0, time.UTC)},
+		{spec: "@monthly", expected: time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{spec: "@every 90s", expected: now.Add(90 * time.Second)},
+		{spec: "@every -1s", err: "must be positive"},
+		{spec: "* * *", err: "expected 5 fields"},
+		{spec: "60 * * * *", err: "invalid value '60', expected 0 to 59"},
+		{spec: "*/0 * * * *", err: "invalid step '0'"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.spec, func(t *testing.T) {
+			s, err := parseSchedule(tc.spec)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, s.Next(now))
+		})
+	}
+}
+
+func TestTriggerSchedule(t *testing.T) {
+	daily := "@daily"
+
+	s, err := triggerSchedule(&TriggerInfo{Type: TriggerTypePolling}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, every(DefaultPollInterval), s)
+
+	s, err = triggerSchedule(&TriggerInfo{Type: TriggerTypeScheduled, Schedule: &daily}, 10*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, every(10*time.Second), s)
+
+	s, err = triggerSchedule(&TriggerInfo{Type: TriggerTypeWebhook}, 0)
+	require.NoError(t, err)
+	assert.Nil(t, s)
+
+	_, err = triggerSchedule(&TriggerInfo{Name: "Report", Type: TriggerTypeScheduled}, 0)
+	require.ErrorContains(t, err, "scheduled trigger 'Report' has no schedule")
+}
@@ -0,0 +1,125 @@
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/samber/lo"
+)
+
+// Mismatch is a difference between the structure of the sample data of a
+// resource and the structure of an actual output.
+type Mismatch struct {
+	// Path locates the value, e.g. "$.messages[].text".
+	Path string `json:"path"`
+	// Sample and Output are the JSON types of the value, empty when it is
+	// missing.
+	Sample string `json:"sample"`
+	Output string `json:"output"`
+}
+
+func (m Mismatch) String() string {
+	switch {
+	case m.Sample == "":
+		return fmt.Sprintf("%s: missing from SampleData(), output has %s", m.Path, m.Output)
+	case m.Output == "":
+		return fmt.Sprintf("%s: missing from output, SampleData() has %s", m.Path, m.Sample)
+	default:
+		return fmt.Sprintf("%s: SampleData() has %s, output has %s", m.Path, m.Sample, m.Output)
+	}
+}
+
+// CompareShape returns where sample and output, two JSON documents, differ in
+// structure: keys missing on either side and values of different types. The
+// values themselves are not compared, and null matches any type. The items of
+// output arrays are compared with the first item of the sample array.
+func CompareShape(sample, output json.RawMessage) ([]Mismatch, error) {
+	var s, o any
+
+	if err := decodeJSON(sample, &s); err != nil {
+		return nil, fmt.Errorf("invalid sample data: %w", err)
+	}
+
+	if err := decodeJSON(output, &o); err != nil {
+		return nil, fmt.Errorf("invalid output: %w", err)
+	}
+
+	var mismatches []Mismatch
+
+	compareShape("$", s, o, &mismatches)
+
+	return lo.UniqBy(mismatches, func(m Mismatch) string { return m.String() }), nil
+}
+
+func decodeJSON(data json.RawMessage, v any) error {
+	if len(data) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+func compareShape(path string, sample, output any, mismatches *[]Mismatch) {
+	sampleType, outputType := jsonType(sample), jsonType(output)
+
+	if sampleType == "null" || outputType == "null" {
+		return
+	}
+
+	if sampleType != outputType {
+		*mismatches = append(*mismatches, Mismatch{Path: path, Sample: sampleType, Output: outputType})
+		return
+	}
+
+	switch s := sample.(type) {
+	case map[string]any:
+		o := output.(map[string]any)
+
+		keys := lo.Union(lo.Keys(s), lo.Keys(o))
+		slices.Sort(keys)
+
+		for _, key := range keys {
+			sv, inSample := s[key]
+			ov, inOutput := o[key]
+
+			switch {
+			case !inSample:
+				*mismatches = append(*mismatches, Mismatch{Path: path + "." + key, Output: jsonType(ov)})
+			case !inOutput:
+				*mismatches = append(*mismatches, Mismatch{Path: path + "." + key, Sample: jsonType(sv)})
+			default:
+				compareShape(path+"."+key, sv, ov, mismatches)
+			}
+		}
+	case []any:
+		o := output.([]any)
+		if len(s) == 0 || len(o) == 0 {
+			return
+		}
+
+		for _, item := range o {
+			compareShape(path+"[]", s[0], item, mismatches)
+		}
+	}
+}
+
+// jsonType names the type of a decoded JSON value.
+func jsonType(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
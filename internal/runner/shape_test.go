@@ -0,0 +1,67 @@
+package runner
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareShape(t *testing.T) {
+	testCases := []struct {
+		name     string
+		sample   string
+		output   string
+		expected []string
+	}{
+		{
+			name:   "same shape, other values",
+			sample: `{"id": "1", "count": 2, "ok": true, "tags": ["a"]}`,
+			output: `{"id": "42", "count": 7, "ok": false, "tags": ["b", "c"]}`,
+		},
+		{
+			name:     "missing keys",
+			sample:   `{"id": "1", "name": "Ada"}`,
+			output:   `{"id": "2", "email": "ada@example.com"}`,
+			expected: []string{"$.email: missing from SampleData(), output has string", "$.name: missing from output, SampleData() has string"},
+		},
+		{
+			name:     "type differences",
+			sample:   `{"id": "1", "user": {"age": "36"}}`,
+			output:   `{"id": 1, "user": {"age": 36}}`,
+			expected: []string{"$.id: SampleData() has string, output has number", "$.user.age: SampleData() has string, output has number"},
+		},
+		{
+			name:     "array items",
+			sample:   `{"messages": [{"text": "hi"}]}`,
+			output:   `{"messages": [{"text": "a"}, {"text": "b", "ts": 1}, {"text": "c", "ts": 2}]}`,
+			expected: []string{"$.messages[].ts: missing from SampleData(), output has number"},
+		},
+		{
+			name:   "null matches anything",
+			sample: `{"deleted_at": null, "items": []}`,
+			output: `{"deleted_at": "2024-01-01", "items": [1]}`,
+		},
+		{
+			name:     "top level",
+			sample:   `{"message": "Hello World!"}`,
+			output:   `["Hello"]`,
+			expected: []string{"$: SampleData() has object, output has array"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mismatches, err := CompareShape(json.RawMessage(tc.sample), json.RawMessage(tc.output))
+			require.NoError(t, err)
+
+			var actual []string
+			for _, m := range mismatches {
+				actual = append(actual, m.String())
+			}
+
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
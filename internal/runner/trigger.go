@@ -0,0 +1,174 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultPollInterval is how often polling triggers without a schedule run.
+const DefaultPollInterval = time.Minute
+
+// Trigger types, as returned by the GetType method of triggers.
+const (
+	TriggerTypePolling   = "polling"
+	TriggerTypeEvent     = "event"
+	TriggerTypeWebhook   = "webhook"
+	TriggerTypeScheduled = "scheduled"
+)
+
+// TriggerInfo describes a trigger of the integration.
+type TriggerInfo struct {
+	Name     string  `json:"name"`
+	Type     string  `json:"type"`
+	Schedule *string `json:"schedule"`
+}
+
+// TriggerMetadata is the state kept by the platform between executions.
+type TriggerMetadata struct {
+	LastRun *time.Time `json:"last_run,omitempty"`
+}
+
+// DescribeTrigger returns the type and schedule of the trigger called name.
+func (r *Runner) DescribeTrigger(name string) (*TriggerInfo, error) {
+	output, err := r.call(request{Op: "describe", Name: name})
+	if err != nil {
+		return nil, err
+	}
+
+	var info TriggerInfo
+	if err := json.Unmarshal(output, &info); err != nil {
+		return nil, fmt.Errorf("invalid trigger description: %w", err)
+	}
+
+	return &info, nil
+}
+
+// StartTrigger runs the Start method of the trigger called name.
+func (r *Runner) StartTrigger(name string, input, auth json.RawMessage) error {
+	_, err := r.call(request{Op: "start", Name: name, Input: input, Auth: auth})
+	return err
+}
+
+// StopTrigger runs the Stop method of the trigger called name.
+func (r *Runner) StopTrigger(name string, input, auth json.RawMessage) error {
+	_, err := r.call(request{Op: "stop", Name: name, Input: input, Auth: auth})
+	return err
+}
+
+// ExecuteTrigger runs the Execute method of the trigger called name.
+func (r *Runner) ExecuteTrigger(name string, input, auth json.RawMessage, metadata TriggerMetadata) (json.RawMessage, error) {
+	return r.call(request{Op: "execute", Name: name, Input: input, Auth: auth, Metadata: metadata})
+}
+
+// TriggerOptions configures RunTrigger.
+type TriggerOptions struct {
+	Input json.RawMessage
+	Auth  json.RawMessage
+
+	// Interval overrides the schedule of the trigger.
+	Interval time.Duration
+	// Once stops the trigger after its first execution.
+	Once bool
+	// LastRun is the last run passed to the first execution.
+	LastRun *time.Time
+}
+
+// Event is an execution of a trigger.
+type Event struct {
+	Time     time.Time
+	Input    json.RawMessage
+	Metadata TriggerMetadata
+	Output   json.RawMessage
+	Err      error
+
+	// Next is when the trigger runs again, zero when it only runs once.
+	Next time.Time
+}
+
+// RunTrigger drives the trigger called name the way the platform does: it is
+// started, executed on its schedule until ctx is cancelled, then stopped.
+// Event and webhook triggers are executed once and kept started. Every
+// execution is passed to emit, a failed one does not stop the trigger.
+func (r *Runner) RunTrigger(ctx context.Context, name string, o TriggerOptions, emit func(Event)) (err error) {
+	info, err := r.DescribeTrigger(name)
+	if err != nil {
+		return err
+	}
+
+	sched, err := triggerSchedule(info, o.Interval)
+	if err != nil {
+		return err
+	}
+
+	if err := r.StartTrigger(name, o.Input, o.Auth); err != nil {
+		return fmt.Errorf("failed to start trigger '%s': %w", info.Name, err)
+	}
+
+	defer func() {
+		if stopErr := r.StopTrigger(name, o.Input, o.Auth); stopErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to stop trigger '%s': %w", info.Name, stopErr))
+		}
+	}()
+
+	metadata := TriggerMetadata{LastRun: o.LastRun}
+
+	for {
+		now := time.Now()
+		event := Event{Time: now, Input: o.Input, Metadata: metadata}
+
+		event.Output, event.Err = r.ExecuteTrigger(name, o.Input, o.Auth, metadata)
+		if event.Err == nil {
+			metadata.LastRun = &now
+		}
+
+		if sched != nil && !o.Once {
+			if event.Next = sched.Next(now); event.Next.IsZero() {
+				return fmt.Errorf("the schedule of trigger '%s' never runs again", info.Name)
+			}
+		}
+
+		emit(event)
+
+		if o.Once {
+			return nil
+		}
+
+		if sched == nil {
+			<-ctx.Done()
+			return nil
+		}
+
+		timer := time.NewTimer(time.Until(event.Next))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+	}
+}
+
+// triggerSchedule returns when the trigger runs, nil when it is only run on
+// events.
+func triggerSchedule(info *TriggerInfo, interval time.Duration) (schedule, error) {
+	if interval > 0 {
+		return every(interval), nil
+	}
+
+	if info.Schedule != nil && *info.Schedule != "" {
+		return parseSchedule(*info.Schedule)
+	}
+
+	switch info.Type {
+	case TriggerTypePolling:
+		return every(DefaultPollInterval), nil
+	case TriggerTypeScheduled:
+		return nil, fmt.Errorf("scheduled trigger '%s' has no schedule in its Criteria(), set one or use an interval", info.Name)
+	default:
+		return nil, nil
+	}
+}
@@ -0,0 +1,117 @@
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/samber/lo"
+)
+
+// Resource is an action or trigger of the integration.
+type Resource struct {
+	Kind   string          `json:"kind"`
+	Name   string          `json:"name"`
+	Sample json.RawMessage `json:"sample"`
+}
+
+// Resources returns the actions then the triggers of the integration, with
+// their sample data.
+func (r *Runner) Resources() ([]Resource, error) {
+	output, err := r.call(request{Op: "list"})
+	if err != nil {
+		return nil, err
+	}
+
+	var resources []Resource
+	if err := json.Unmarshal(output, &resources); err != nil {
+		return nil, fmt.Errorf("invalid resource list: %w", err)
+	}
+
+	return resources, nil
+}
+
+// SampleReport is the verification of the sample data of a resource.
+type SampleReport struct {
+	Kind     string `json:"kind"`
+	Name     string `json:"name"`
+	Fixtures int    `json:"fixtures"`
+	// Mismatches between the sample data and the outputs of all fixtures.
+	Mismatches []Mismatch `json:"mismatches,omitempty"`
+	// Errors of the fixtures that could not be loaded or replayed.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// OK reports whether the sample data matches every output.
+func (s *SampleReport) OK() bool {
+	return len(s.Mismatches) == 0 && len(s.Errors) == 0
+}
+
+// VerifySamples replays the fixtures of every resource, found in root, and
+// compares the structure of their outputs with the sample data of the
+// resource. Resources without fixtures are reported with none, and those
+// whose fixtures fail to load or replay with the errors.
+func (r *Runner) VerifySamples(root string, auth json.RawMessage) ([]SampleReport, error) {
+	resources, err := r.Resources()
+	if err != nil {
+		return nil, err
+	}
+
+	reports := make([]SampleReport, 0, len(resources))
+
+	for _, resource := range resources {
+		reports = append(reports, r.verifySample(resource, FixturesDir(root, resource.Kind, resource.Name), auth))
+	}
+
+	return reports, nil
+}
+
+// verifySample replays the fixtures of resource kept in dir. Failures go in
+// the report so that the other resources are still verified.
+func (r *Runner) verifySample(resource Resource, dir string, auth json.RawMessage) SampleReport {
+	report := SampleReport{Kind: resource.Kind, Name: resource.Name}
+
+	fixtures, err := LoadFixtures(dir)
+	if err != nil {
+		report.Errors = append(report.Errors, err.Error())
+		return report
+	}
+
+	if report.Fixtures = len(fixtures); report.Fixtures == 0 {
+		return report
+	}
+
+	// a trigger failing to stop still has its replays
+	replays, err := r.ReplayFixtures(resource.Kind, resource.Name, dir, auth)
+	if err != nil {
+		report.Errors = append(report.Errors, err.Error())
+	}
+
+	for _, replay := range replays {
+		// a run recorded failing has no output to compare, it must only fail
+		// the same way again
+		if replay.Fixture.Error != "" {
+			if replay.Diff != "" {
+				report.Errors = append(report.Errors, fmt.Sprintf("%s: the recorded error changed\n%s", replay.Fixture.Path, replay.Diff))
+			}
+
+			continue
+		}
+
+		if replay.Err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("%s: %v", replay.Fixture.Path, replay.Err))
+			continue
+		}
+
+		mismatches, err := CompareShape(resource.Sample, replay.Output)
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("%s: %v", replay.Fixture.Path, err))
+			continue
+		}
+
+		report.Mismatches = append(report.Mismatches, mismatches...)
+	}
+
+	report.Mismatches = lo.UniqBy(report.Mismatches, func(m Mismatch) string { return m.String() })
+
+	return report
+}
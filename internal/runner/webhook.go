@@ -0,0 +1,243 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// maxWebhookBody limits the size of the requests forwarded to triggers.
+	maxWebhookBody = 10 << 20
+
+	// redactedValue replaces the values of redacted headers and query
+	// parameters.
+	redactedValue = "[REDACTED]"
+)
+
+// RedactedHeaders are the headers holding credentials or signatures that are
+// redacted from recorded webhook requests.
+var RedactedHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+	"X-Api-Key",
+	"X-Auth-Token",
+	"X-Csrf-Token",
+	"X-Gitlab-Token",
+	"X-Hub-Signature",
+	"X-Hub-Signature-256",
+	"X-Slack-Signature",
+	"X-Shopify-Hmac-Sha256",
+	"X-Twilio-Signature",
+	"Stripe-Signature",
+	"X-Signature",
+	"X-Webhook-Signature",
+}
+
+// RedactedQuery are the query parameters holding credentials or signatures
+// that are redacted from recorded webhook requests.
+var RedactedQuery = []string{
+	"token",
+	"access_token",
+	"refresh_token",
+	"id_token",
+	"api_key",
+	"apikey",
+	"key",
+	"secret",
+	"client_secret",
+	"password",
+	"code",
+	"signature",
+	"sig",
+	"hmac",
+	"hub.verify_token",
+	"validationToken",
+}
+
+// WebhookRequest is what a webhook trigger receives of an HTTP request. Its
+// fields are added to the input of the trigger.
+type WebhookRequest struct {
+	Method  string            `json:"method"`
+	Path    string            `json:"path"`
+	Headers map[string]string `json:"headers"`
+	Query   map[string]string `json:"query"`
+	// Body is the JSON body of the request, or the body as a JSON string.
+	Body json.RawMessage `json:"body"`
+}
+
+// NewWebhookRequest reads req. Headers and query parameters with several
+// values have them joined with commas.
+func NewWebhookRequest(req *http.Request) (*WebhookRequest, error) {
+	body, err := io.ReadAll(http.MaxBytesReader(nil, req.Body, maxWebhookBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the body: %w", err)
+	}
+
+	w := &WebhookRequest{
+		Method:  req.Method,
+		Path:    req.URL.Path,
+		Headers: joinValues(req.Header),
+		Query:   joinValues(req.URL.Query()),
+		Body:    json.RawMessage("null"),
+	}
+
+	switch {
+	case len(body) == 0:
+	case json.Valid(body):
+		w.Body = body
+	default:
+		if w.Body, err = json.Marshal(string(body)); err != nil {
+			return nil, err
+		}
+	}
+
+	return w, nil
+}
+
+// Redact returns a copy of w with the values of the headers called headers
+// and of the query parameters called query, whatever their case, replaced.
+func (w *WebhookRequest) Redact(headers, query []string) *WebhookRequest {
+	redacted := *w
+	redacted.Headers = redactValues(w.Headers, headers)
+	redacted.Query = redactValues(w.Query, query)
+
+	return &redacted
+}
+
+func redactValues(values map[string]string, names []string) map[string]string {
+	redacted := maps.Clone(values)
+	for key := range redacted {
+		if slices.ContainsFunc(names, func(name string) bool { return strings.EqualFold(name, key) }) {
+			redacted[key] = redactedValue
+		}
+	}
+
+	return redacted
+}
+
+func joinValues(values map[string][]string) map[string]string {
+	joined := make(map[string]string, len(values))
+	for key, vals := range values {
+		joined[key] = strings.Join(vals, ",")
+	}
+
+	return joined
+}
+
+// Input returns the properties of the trigger, a JSON object, with the fields
+// of the request added.
+func (w *WebhookRequest) Input(properties json.RawMessage) (json.RawMessage, error) {
+	input := map[string]any{}
+	if len(properties) > 0 {
+		if err := json.Unmarshal(properties, &input); err != nil {
+			return nil, fmt.Errorf("the input must be a JSON object: %w", err)
+		}
+	}
+
+	maps.Copy(input, map[string]any{
+		"method":  w.Method,
+		"path":    w.Path,
+		"headers": w.Headers,
+		"query":   w.Query,
+		"body":    w.Body,
+	})
+
+	return json.Marshal(input)
+}
+
+// ServeWebhook starts the trigger called name and executes it with every
+// request received on l until ctx is cancelled, then stops it. The output of
+// the trigger is sent back as the JSON response. Every execution is passed to
+// emit.
+func (r *Runner) ServeWebhook(ctx context.Context, l net.Listener, name string, o TriggerOptions, emit func(Event, *WebhookRequest)) (err error) {
+	info, err := r.DescribeTrigger(name)
+	if err != nil {
+		return err
+	}
+
+	if err := r.StartTrigger(name, o.Input, o.Auth); err != nil {
+		return fmt.Errorf("failed to start trigger '%s': %w", info.Name, err)
+	}
+
+	defer func() {
+		if stopErr := r.StopTrigger(name, o.Input, o.Auth); stopErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to stop trigger '%s': %w", info.Name, stopErr))
+		}
+	}()
+
+	var mu sync.Mutex
+
+	metadata := TriggerMetadata{LastRun: o.LastRun}
+
+	server := &http.Server{
+		ReadHeaderTimeout: 10 * time.Second,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			webhook, err := NewWebhookRequest(req)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			input, err := webhook.Input(o.Input)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+
+			// executions are emitted in order, each with the last run of the previous one
+			mu.Lock()
+			defer mu.Unlock()
+
+			event := Event{Time: time.Now(), Input: input, Metadata: metadata}
+
+			event.Output, event.Err = r.ExecuteTrigger(name, input, o.Auth, metadata)
+			if event.Err == nil {
+				metadata.LastRun = &event.Time
+			}
+
+			emit(event, webhook)
+
+			w.Header().Set("Content-Type", "application/json")
+
+			if event.Err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				_ = json.NewEncoder(w).Encode(map[string]string{"error": event.Err.Error()})
+
+				return
+			}
+
+			if len(event.Output) == 0 {
+				event.Output = json.RawMessage("null")
+			}
+
+			_, _ = w.Write(event.Output)
+		}),
+	}
+
+	serveErr := make(chan error, 1)
+
+	go func() { serveErr <- server.Serve(l) }()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return server.Shutdown(shutdownCtx)
+}
@@ -0,0 +1,92 @@
+package runner
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookRequestInput(t *testing.T) {
+	testCases := []struct {
+		name       string
+		body       string
+		properties string
+		expected   string
+		err        string
+	}{
+		{
+			name:     "json body",
+			body:     `{"text": "hi"}`,
+			expected: `{"method": "POST", "path": "/hooks/slack", "headers": {"X-Signature": "a,b"}, "query": {"team": "acme"}, "body": {"text": "hi"}}`,
+		},
+		{
+			name:     "text body",
+			body:     "text=hi",
+			expected: `{"method": "POST", "path": "/hooks/slack", "headers": {"X-Signature": "a,b"}, "query": {"team": "acme"}, "body": "text=hi"}`,
+		},
+		{
+			name:       "properties",
+			properties: `{"name": "Ada", "body": "replaced"}`,
+			expected:   `{"name": "Ada", "method": "POST", "path": "/hooks/slack", "headers": {"X-Signature": "a,b"}, "query": {"team": "acme"}, "body": null}`,
+		},
+		{
+			name:       "properties not an object",
+			properties: `["Ada"]`,
+			err:        "the input must be a JSON object",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/hooks/slack?team=acme", strings.NewReader(tc.body))
+			req.Header.Add("X-Signature", "a")
+			req.Header.Add("X-Signature", "b")
+
+			webhook, err := NewWebhookRequest(req)
+			require.NoError(t, err)
+
+			input, err := webhook.Input(json.RawMessage(tc.properties))
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.expected, string(input))
+		})
+	}
+}
+
+func TestWebhookRequestRedact(t *testing.T) {
+	req := httptest.NewRequest("POST", "/hooks/slack?token=abc&API_KEY=def&tenant=acme&x-custom=ghi", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("X-Slack-Signature", "v0=abc")
+	req.Header.Set("X-Custom-Secret", "s3cr3t")
+	req.Header.Set("Content-Type", "application/json")
+
+	webhook, err := NewWebhookRequest(req)
+	require.NoError(t, err)
+
+	redacted := webhook.Redact(slices.Concat(RedactedHeaders, []string{"x-custom-secret"}), slices.Concat(RedactedQuery, []string{"X-Custom"}))
+	assert.Equal(t, map[string]string{
+		"Authorization":     "[REDACTED]",
+		"X-Slack-Signature": "[REDACTED]",
+		"X-Custom-Secret":   "[REDACTED]",
+		"Content-Type":      "application/json",
+	}, redacted.Headers)
+	assert.Equal(t, map[string]string{
+		"token":    "[REDACTED]",
+		"API_KEY":  "[REDACTED]",
+		"x-custom": "[REDACTED]",
+		"tenant":   "acme",
+	}, redacted.Query)
+
+	// the request is left untouched
+	assert.Equal(t, "Bearer secret", webhook.Headers["Authorization"])
+	assert.Equal(t, "abc", webhook.Query["token"])
+}
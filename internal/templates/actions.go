@@ -1,25 +1,27 @@
 package templates
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
-	"os"
+	"go/format"
+	"go/token"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
 
-	"github.com/BurntSushi/toml"
 	"github.com/manifoldco/promptui"
 	"github.com/samber/lo"
-	"github.com/spf13/cobra"
-	"github.com/wakflo/go-sdk/client"
 	"github.com/wakflo/go-sdk/sdk"
 )
 
 const integrationFile = "flo.toml"
 const readmeFile = "README.md"
-const libFile = "lib.go"
+
+// resourceFileName matches the file names formatFileName may generate
+// resources in.
+var resourceFileName = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
 
 type ActionTriggerMetadata struct {
 	Name        string
@@ -31,120 +33,165 @@ type ActionTriggerMetadata struct {
 	Kind        string // either "action" or "trigger"
 }
 
-func HandleAddResource(kind string, cmd *cobra.Command, floClient *client.Client) error {
-	// Ensure the command is being run from an integration folder
-	data, err := os.ReadFile(integrationFile)
-	if errors.Is(err, os.ErrNotExist) {
-		return fmt.Errorf("not an integration project: missing '%s' file", integrationFile)
-	}
-
-	var schema sdk.IntegrationSchemaModel
-	if err := toml.Unmarshal(data, &schema); err != nil {
-		return fmt.Errorf("failed to parse '%s' file: %w", integrationFile, err)
-	}
+// AddResourceInput holds the values of a new action or trigger that are known
+// up front, e.g. from command line flags. Missing values are prompted for when
+// Interactive is set and generated or defaulted otherwise. AcceptDefaults
+// skips the prompts of the values that have a default.
+type AddResourceInput struct {
+	Name           string
+	Description    string
+	Type           string
+	Interactive    bool
+	AcceptDefaults bool
+}
 
-	if _, err := os.Stat(libFile); errors.Is(err, os.ErrNotExist) {
-		return fmt.Errorf("missing 'lib.go' file in the integration project")
+// HandleAddResource scaffolds an action or trigger in the integration project
+// p and registers it in doc.go, lib.go and the README.
+func HandleAddResource(ctx context.Context, kind string, input *AddResourceInput, gen Generator, p *Project) error {
+	// Ensure the command is being run from an integration folder
+	project, err := readIntegrationFile(p, integrationFile)
+	if err != nil {
+		return err
 	}
 
-	meta, err := collectInput(kind, &schema, floClient)
+	meta, err := collectInput(ctx, kind, input, &project.Integration, gen)
 	if err != nil {
 		return err
 	}
 
-	// Create resource folder
 	resourceFolder := kind + "s"
-	if _, err := os.Stat(resourceFolder); errors.Is(err, os.ErrNotExist) {
-		if err := os.MkdirAll(resourceFolder, os.ModePerm); err != nil {
-			return fmt.Errorf("failed to create resource folder: %w", err)
-		}
+	resourceFileName := filepath.Join(resourceFolder, meta.FileName+".go")
+
+	// Locate where to register the constructor before rendering anything. A
+	// resource being generated again is already registered.
+	libEdit, err := planLibEdit(p, ".", meta)
+	if errors.Is(err, errRegistered) && p.exists(resourceFileName) {
+		libEdit = nil
+	} else if err != nil {
+		return err
 	}
 
-	// Create resource file
-	resourceFileName := filepath.Join(resourceFolder, meta.FileName+".go")
-	if err := WriteTemplateToFile(resourceFileName, getResourceTemplate(kind), meta); err != nil {
+	// Render the resource, documentation and any other file of the template pack
+	changes, err := p.pack().render(kind, meta)
+	if err != nil {
 		return fmt.Errorf("failed to create %s: %w", kind, err)
 	}
 
-	// Create documentation (Markdown) file
-	docFileName := filepath.Join(resourceFolder, meta.FileName+".md")
-	if err := WriteTemplateToFile(docFileName, getDocTemplate, meta); err != nil {
-		return fmt.Errorf("failed to create %s documentation: %w", kind, err)
-	}
+	// Regenerate the `doc.go` file
+	docChange, err := docFileChange(p, resourceFolder, kind, func(mdFiles []string) []string {
+		for _, change := range changes {
+			if filepath.Dir(change.path) == resourceFolder && filepath.Ext(change.path) == ".md" {
+				mdFiles = append(mdFiles, change.path)
+			}
+		}
 
-	// Update or create the `doc.go` file
-	docFilePath := filepath.Join(resourceFolder, "doc.go")
-	if err := updateDocFile(docFilePath, kind, resourceFolder); err != nil {
+		return mdFiles
+	})
+	if err != nil {
 		return fmt.Errorf("failed to update 'doc.go': %w", err)
 	}
 
-	// Update the code.go file
-	if err := updateLibFile(libFile, meta); err != nil {
-		return fmt.Errorf("failed to update 'code.go': %w", err)
-	}
-
 	// Update the README file with a list of actions or triggers
-	if err := updateReadmeFile(readmeFile, kind, meta); err != nil {
+	readme, err := addReadmeRow(p, readmeFile, kind, meta)
+	if err != nil {
 		return fmt.Errorf("failed to update 'README.md': %w", err)
 	}
 
-	fmt.Printf("%s '%s' created successfully.\n", strings.Title(kind), meta.Name)
+	changes = append(changes, docChange)
+
+	if libEdit != nil {
+		changes = append(changes, fileChange{path: libEdit.path, content: libEdit.content})
+	}
+
+	changes = append(changes, fileChange{path: readmeFile, content: readme})
+
+	if err := p.commit(changes); err != nil {
+		return err
+	}
+
+	p.printf("%s '%s' created successfully.\n", strings.Title(kind), meta.Name)
+
 	return nil
 }
 
-// Collects inputs interactively
-func collectInput(kind string, schema *sdk.IntegrationSchemaModel, floClient *client.Client) (*ActionTriggerMetadata, error) {
-	prompt := promptui.Prompt{
-		Label: "Enter Name",
-	}
+// collectInput completes input with prompts or generated values
+func collectInput(ctx context.Context, kind string, input *AddResourceInput, schema *sdk.IntegrationSchemaModel, gen Generator) (*ActionTriggerMetadata, error) {
+	typeOptions := getTypeOptions(kind)
 
-	name, err := prompt.Run()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get name: %w", err)
+	name := input.Name
+	if name == "" {
+		if !input.Interactive {
+			return nil, errors.New("--name is required when not running interactively")
+		}
+
+		prompt := promptui.Prompt{
+			Label: "Enter Name",
+		}
+
+		var err error
+		name, err = prompt.Run()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get name: %w", err)
+		}
 	}
 
-	descMessage := fmt.Sprintf("%s integration %s called %s", schema.Name, kind, name)
-	generateResponse, err := floClient.Rest.GenerateDescription(context.Background(), client.RestGenerateDescriptionRequest{
-		Prompt: descMessage,
-		Type:   kind,
-	})
-	if err != nil {
+	if err := validateResourceName(kind, name); err != nil {
 		return nil, err
 	}
 
-	descPrompt := promptui.Prompt{
-		Label:     "Enter Description",
-		Default:   generateResponse.Data,
-		AllowEdit: true,
-	}
-	description, err := descPrompt.Run()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get description: %w", err)
+	selectedType := ""
+	if input.Type != "" {
+		typ, err := parseResourceType(kind, input.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		selectedType = typ
 	}
 
-	// Interactive selection for type
-	var typeOptions []string
-	if kind == "action" {
-		typeOptions = []string{
-			"Normal",
-		}
-	} else {
-		typeOptions = []string{
-			"Polling",
-			"Event",
-			"Webhook",
-			"Scheduled",
+	description := input.Description
+	if description == "" {
+		descMessage := fmt.Sprintf("%s integration %s called %s", schema.Name, kind, name)
+		generated, err := gen.Description(ctx, kind, descMessage)
+		if err != nil {
+			return nil, err
 		}
-	}
 
-	typePrompt := promptui.Select{
-		Label: fmt.Sprintf("Select %s Type", strings.Title(kind)),
-		Items: typeOptions,
+		description = generated
+
+		if input.Interactive && !input.AcceptDefaults {
+			descPrompt := promptui.Prompt{
+				Label:     "Enter Description",
+				Default:   generated,
+				AllowEdit: true,
+			}
+			description, err = descPrompt.Run()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get description: %w", err)
+			}
+		}
 	}
 
-	_, selectedType, err := typePrompt.Run()
-	if err != nil {
-		return nil, fmt.Errorf("failed to select type: %w", err)
+	if selectedType == "" {
+		switch {
+		case len(typeOptions) == 1:
+			selectedType = typeOptions[0]
+		case !input.Interactive:
+			return nil, fmt.Errorf("--type is required when not running interactively, expected one of: %s", strings.Join(typeOptions, ", "))
+		default:
+			// Interactive selection for type
+			typePrompt := promptui.Select{
+				Label: fmt.Sprintf("Select %s Type", strings.Title(kind)),
+				Items: typeOptions,
+			}
+
+			_, typ, err := typePrompt.Run()
+			if err != nil {
+				return nil, fmt.Errorf("failed to select type: %w", err)
+			}
+
+			selectedType = typ
+		}
 	}
 
 	meta := &ActionTriggerMetadata{
@@ -160,315 +207,122 @@ func collectInput(kind string, schema *sdk.IntegrationSchemaModel, floClient *cl
 	return meta, nil
 }
 
-func getSDKTypeName(kind, typ string) string {
+func getTypeOptions(kind string) []string {
 	if kind == "action" {
-		return fmt.Sprintf("sdkcore.ActionType%s", typ)
-	}
-	return fmt.Sprintf("sdkcore.TriggerType%s", typ)
-}
-
-func formatFileName(name string) string {
-	// Format the name to make it file-safe (lowercase and underscores)
-	return strings.ToLower(strings.ReplaceAll(name, " ", "_"))
-}
-
-func getConstructorName(kind, name string) string {
-	return fmt.Sprintf("%s.New%s%s", kind+"s", lo.PascalCase(name), strings.Title(kind))
-}
-
-func updateLibFile(filePath string, meta *ActionTriggerMetadata) error {
-	// Read the existing code.go file
-	codeData, err := os.ReadFile(filePath)
-	if err != nil {
-		return err
+		return []string{
+			"Normal",
+		}
 	}
 
-	// Define the insertion point based on whether it's an action or trigger
-	var marker string
-	if meta.Kind == "action" {
-		marker = "return []sdk.Action{"
-	} else {
-		marker = "return []sdk.Trigger{"
-	}
-	insertionPoint := strings.Index(string(codeData), marker)
-	if insertionPoint == -1 {
-		return fmt.Errorf("failed to find the '%s' section in 'code.go'", marker)
+	return []string{
+		"Polling",
+		"Event",
+		"Webhook",
+		"Scheduled",
 	}
-
-	// Prepare the line to insert
-	newLine := fmt.Sprintf("\t\t%s(),", meta.Constructor)
-
-	// Split the file content and insert the new line
-	var buffer bytes.Buffer
-	buffer.Write(codeData[:insertionPoint+len(marker)])
-	buffer.WriteString("\n" + newLine + "\n")
-	buffer.Write(codeData[insertionPoint+len(marker):])
-
-	// Write back the updated content to code.go
-	return os.WriteFile(filePath, buffer.Bytes(), 0644)
 }
 
-func updateDocFile(docFilePath, kind, resourceFolder string) error {
-	// Read the existing doc.go content if it exists
-	content := ""
-	if _, err := os.Stat(docFilePath); err == nil {
-		_, err := os.ReadFile(docFilePath)
-		if err != nil {
-			return fmt.Errorf("failed to read existing doc.go: %w", err)
-		}
-		// content = string(data)
-	}
+// parseResourceType accepts a type either by its short name ("polling") or
+// by its SDK name ("sdkcore.TriggerTypePolling") and returns the short name.
+func parseResourceType(kind, typ string) (string, error) {
+	short := strings.TrimPrefix(typ, "sdkcore.")
+	short = strings.TrimPrefix(short, strings.Title(kind)+"Type")
 
-	// Find all Markdown files in the resource folder
-	matches, err := filepath.Glob(filepath.Join(resourceFolder, "*.md"))
-	if err != nil {
-		return fmt.Errorf("failed to find markdown files: %w", err)
-	}
-
-	// Prepare the new embed declarations
-	newEmbeds := ""
-	for _, match := range matches {
-		fileName := filepath.Base(match)
-		varName := strings.TrimSuffix(fileName, filepath.Ext(fileName)) + "Docs"
-		varName = strings.ReplaceAll(varName, " ", "")
-		varName = strings.ToLower(varName[:1]) + varName[1:] // Follow Go variable naming convention
-
-		// Add the embed only if not already in the content
-		if !strings.Contains(content, varName) {
-			newEmbeds += fmt.Sprintf("//go:embed %s\nvar %s string\n\n", fileName, lo.CamelCase(varName))
+	for _, option := range getTypeOptions(kind) {
+		if strings.EqualFold(option, short) {
+			return option, nil
 		}
 	}
 
-	// If no new embeds are found, do nothing
-	if newEmbeds == "" {
-		return nil
-	}
-
-	// Ensure the package declaration exists
-	if !strings.Contains(content, "package "+kind+"s") {
-		content = fmt.Sprintf("package %ss\n\nimport _ \"embed\"\n\n", kind) + content
-	}
-
-	// Append new embed declarations to the content
-	content += newEmbeds
-
-	// Write back the updated content to doc.go
-	if err := os.WriteFile(docFilePath, []byte(content), 0644); err != nil {
-		return fmt.Errorf("failed to write doc.go: %w", err)
-	}
-
-	return nil
+	return "", fmt.Errorf("invalid %s type '%s', expected one of: %s", kind, typ, strings.Join(getTypeOptions(kind), ", "))
 }
 
-func getResourceTemplate(kind string) string {
-	switch kind {
-	case "action":
-		return actionTemplate
-	case "trigger":
-		return triggerTemplate
-	default:
-		return ""
+func getSDKTypeName(kind, typ string) string {
+	if kind == "action" {
+		return fmt.Sprintf("sdkcore.ActionType%s", typ)
 	}
+	return fmt.Sprintf("sdkcore.TriggerType%s", typ)
 }
 
-// Templates
-const actionTemplate = `package actions
-
-import (
-	"fmt"
-	"github.com/wakflo/go-sdk/autoform"
-	sdkcore "github.com/wakflo/go-sdk/core"
-	"github.com/wakflo/go-sdk/sdk"
-)
-
-type {{ .FileName | toCamelCase }}ActionProps struct {
-	Name string ` + "`json:\"name\"`" + `
-}
-
-type {{ .FileName | toPascal }}Action struct{}
-
-func (a *{{ .FileName | toPascal }}Action) Name() string {
-	return "{{ .Name }}"
-}
-
-func (a *{{ .FileName | toPascal }}Action) Description() string {
-	return "{{ .Description }}"
+func formatFileName(name string) string {
+	// Format the name to make it file-safe (lowercase and underscores)
+	return strings.ToLower(strings.ReplaceAll(name, " ", "_"))
 }
 
-func (a *{{ .FileName | toPascal }}Action) GetType() sdkcore.ActionType {
-	return {{ .TypeName }}
-}
+// validateResourceName checks that name yields a file name inside the resource
+// folder and valid Go identifiers, before anything is generated from it.
+func validateResourceName(kind, name string) error {
+	fileName := formatFileName(name)
+	typeName := lo.PascalCase(fileName) + strings.Title(kind)
 
-func (a *{{ .FileName | toPascal }}Action) Documentation() *sdk.OperationDocumentation {
-	return &sdk.OperationDocumentation{
-		Documentation: &{{ .FileName | toCamelCase }}Docs,
+	if !resourceFileName.MatchString(fileName) || !filepath.IsLocal(fileName+".go") || !token.IsIdentifier(typeName) {
+		return fmt.Errorf("invalid %s name '%s', it must start with a letter and only contain letters, digits, spaces, '-' and '_'", kind, name)
 	}
-}
 
-func (a *{{ .FileName | toPascal }}Action) Icon() *string {
 	return nil
 }
 
-func (a *{{ .FileName | toPascal }}Action) Properties() map[string]*sdkcore.AutoFormSchema {
-	return map[string]*sdkcore.AutoFormSchema{
-		"name": autoform.NewShortTextField().
-			SetLabel("Name").
-			SetRequired(true).
-			SetPlaceholder("Your name").
-			Build(),
-	}
+func getConstructorName(kind, name string) string {
+	return fmt.Sprintf("%s.New%s%s", kind+"s", lo.PascalCase(name), strings.Title(kind))
 }
 
-func (a *{{ .FileName | toPascal }}Action) Perform(ctx sdk.PerformContext) (sdkcore.JSON, error) {
-	input, err := sdk.InputToTypeSafely[{{ .FileName | toCamelCase }}ActionProps](ctx.BaseContext)
+// docFileChange regenerates the doc.go of resourceFolder from the Markdown
+// files it will contain once edit has been applied to the current ones.
+func docFileChange(p *Project, resourceFolder, kind string, edit func(mdFiles []string) []string) (fileChange, error) {
+	matches, err := p.glob(filepath.Join(resourceFolder, "*.md"))
 	if err != nil {
-		return nil, err
-	}
-
-	// implement action logic
-	out := map[string]any{
-		"message": fmt.Sprintf("Hello %s!", input.Name),
-	}
-	
-	
-	return out, nil
-}
-
-func (a *{{ .FileName | toPascal }}Action) Auth() *sdk.Auth {
-	return nil
-}
-
-func (a *{{ .FileName | toPascal }}Action) SampleData() sdkcore.JSON {
-	return map[string]any{
-		"message": "Hello World!",
+		return fileChange{}, fmt.Errorf("failed to find markdown files: %w", err)
 	}
-}
 
-func (a *{{ .FileName | toPascal }}Action) Settings() sdkcore.ActionSettings {
-	return sdkcore.ActionSettings{}
-}
+	docFilePath := filepath.Join(resourceFolder, docFile)
 
-func New{{ .FileName | toPascal }}Action() sdk.Action {
-	return &{{ .FileName | toPascal }}Action{}
-}
-`
-
-const triggerTemplate = `package triggers
-
-import (
-	"context"
-	"fmt"
-	"github.com/wakflo/go-sdk/autoform"
-	sdkcore "github.com/wakflo/go-sdk/core"
-	"github.com/wakflo/go-sdk/sdk"
-)
-
-type {{ .FileName | toCamelCase }}TriggerProps struct {
-	Name string ` + "`json:\"name\"`" + `
-}
-
-type {{ .FileName | toPascal }}Trigger struct{}
-
-func (t *{{ .FileName | toPascal }}Trigger) Name() string {
-	return "{{ .Name }}"
-}
-
-func (t *{{ .FileName | toPascal }}Trigger) Description() string {
-	return "{{ .Description }}"
-}
-
-func (t *{{ .FileName | toPascal }}Trigger) GetType() sdkcore.TriggerType {
-	return {{ .TypeName }}
-}
-
-func (t *{{ .FileName | toPascal }}Trigger) Documentation() *sdk.OperationDocumentation {
-	return &sdk.OperationDocumentation{
-		Documentation: &{{ .FileName | toCamelCase }}Docs,
-	}
-}
-
-func (t *{{ .FileName | toPascal }}Trigger) Icon() *string {
-	return nil
-}
-
-func (t *{{ .FileName | toPascal }}Trigger) Properties() map[string]*sdkcore.AutoFormSchema {
-	return map[string]*sdkcore.AutoFormSchema{
-		"name": autoform.NewShortTextField().
-			SetLabel("Name").
-			SetRequired(true).
-			SetPlaceholder("Your name").
-			Build(),
-	}
-}
-
-// Start initializes the {{ .FileName | toCamelCase }}Trigger, required for event and webhook triggers in a lifecycle context.
-func (t *{{ .FileName | toPascal }}Trigger) Start(ctx sdk.LifecycleContext) error {
-	// Required for event and webhook triggers
-	return nil
-}
-
-// Stop shuts down the {{ .FileName | toCamelCase }}Trigger, cleaning up resources and performing necessary teardown operations.
-func (t *{{ .FileName | toPascal }}Trigger) Stop(ctx sdk.LifecycleContext) error {
-	return nil
-}
-
-// Execute performs the main action logic of {{ .FileName | toCamelCase }}Trigger by processing the input context and returning a JSON response.
-// It converts the base context input into a strongly-typed structure, executes the desired logic, and generates output.
-// Returns a JSON output map with the resulting data or an error if operation fails. required for Pooling triggers
-func (t *{{ .FileName | toPascal }}Trigger) Execute(ctx sdk.ExecuteContext) (sdkcore.JSON, error) {
-	input, err := sdk.InputToTypeSafely[{{ .FileName | toCamelCase }}TriggerProps](ctx.BaseContext)
+	content, err := renderDocFile(kind, edit(matches))
 	if err != nil {
-		return nil, err
+		return fileChange{}, err
 	}
 
-	// implement action logic
-	out := map[string]any{
-		"message": fmt.Sprintf("Triggered by %s!", input.Name),
-	}
-
-	return out, nil
+	return fileChange{path: docFilePath, content: content, summary: "regenerated " + docFilePath}, nil
 }
 
-func (t *{{ .FileName | toPascal }}Trigger) Criteria(ctx context.Context) sdkcore.TriggerCriteria {
-	return sdkcore.TriggerCriteria{}
-}
-
-func (t *{{ .FileName | toPascal }}Trigger) Auth() *sdk.Auth {
-	return nil
-}
+// renderDocFile renders a doc.go embedding every Markdown file in mdFiles into
+// a variable named the way the resource templates reference it.
+func renderDocFile(kind string, mdFiles []string) ([]byte, error) {
+	fileNames := lo.Map(mdFiles, func(match string, _ int) string {
+		return strings.TrimSuffix(filepath.Base(match), ".md")
+	})
+	sort.Strings(fileNames)
 
-func (t *{{ .FileName | toPascal }}Trigger) SampleData() sdkcore.JSON {
-	return map[string]any{
-		"message": "Hello World!",
+	content, err := renderTemplate(docFile, docGoTemplate, map[string]any{"Package": kind + "s", "FileNames": lo.Uniq(fileNames)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to render %s: %w", docFile, err)
 	}
-}
 
-func New{{ .FileName | toPascal }}Trigger() sdk.Trigger {
-	return &{{ .FileName | toPascal }}Trigger{}
+	return format.Source(content)
 }
-`
-
-const getDocTemplate = `
-# {{ .Name }}
 
-## Description
+// docGoTemplate renders the doc.go embedding the Markdown files of a
+// resource folder. It is not part of template packs as commands regenerate it.
+const docGoTemplate = `// Code generated by wakflo from the Markdown files in this folder. DO NOT EDIT.
 
-{{ .Description }}
+package {{ .Package }}
 
-## Details
+import _ "embed"
+{{ range .FileNames }}
+//go:embed {{ . }}.md
+var {{ . | toCamelCase }}Docs string
+{{ end }}`
 
-- **Type**: {{ .TypeName }}
-`
-
-func updateReadmeFile(readmePath, kind string, meta *ActionTriggerMetadata) error {
+// addReadmeRow returns the README at readmePath with a row for the resource
+// added to its actions or triggers table, creating the file when missing.
+func addReadmeRow(p *Project, readmePath, kind string, meta *ActionTriggerMetadata) ([]byte, error) {
 	var readmeContent string
 
 	// Check if README.md exists
-	if _, err := os.Stat(readmePath); err == nil {
+	if p.exists(readmePath) {
 		// Read the existing content of the README file
-		content, err := os.ReadFile(readmePath)
+		content, err := p.readFile(readmePath)
 		if err != nil {
-			return fmt.Errorf("failed to read README.md file: %w", err)
+			return nil, fmt.Errorf("failed to read README.md file: %w", err)
 		}
 		readmeContent = string(content)
 	} else {
@@ -487,8 +341,9 @@ func updateReadmeFile(readmePath, kind string, meta *ActionTriggerMetadata) erro
 	if sectionStart == -1 {
 		// If section doesn't exist, initialize it with a table structure
 		sectionContent = fmt.Sprintf("%s\n\n| Name | Description | Link |\n|------|-------------|------|\n", sectionHeader)
-		readmeContent += sectionContent
-		sectionStart = len(readmeContent) - len(sectionContent)
+		if !strings.HasSuffix(readmeContent, "\n\n") {
+			readmeContent = strings.TrimRight(readmeContent, "\n") + "\n\n"
+		}
 	} else {
 		// Extract the existing section from the content
 		sectionEnd := strings.Index(readmeContent[sectionStart:], "\n## ")
@@ -514,16 +369,11 @@ func updateReadmeFile(readmePath, kind string, meta *ActionTriggerMetadata) erro
 			// Ensure there is no extra newline before appending the new row
 			sectionContent += "\n"
 		}
-		sectionContent += newRow
+		sectionContent += newRow + "\n"
 	}
 
 	// Rebuild the README content
 	readmeContent += sectionContent
 
-	// Write the updated README content back to the file
-	if err := os.WriteFile(readmePath, []byte(readmeContent), 0644); err != nil {
-		return fmt.Errorf("failed to write updated README.md file: %w", err)
-	}
-
-	return nil
+	return []byte(readmeContent), nil
 }
@@ -0,0 +1,134 @@
+package templates
+
+import (
+	"context"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wakflo/go-sdk/sdk"
+	"github.com/wakflo/wakflo-cli/internal/testutil"
+)
+
+func TestParseResourceType(t *testing.T) {
+	testCases := []struct {
+		name     string
+		kind     string
+		typ      string
+		expected string
+		err      bool
+	}{
+		{name: "short name", kind: "trigger", typ: "polling", expected: "Polling"},
+		{name: "sdk name", kind: "trigger", typ: "sdkcore.TriggerTypeWebhook", expected: "Webhook"},
+		{name: "action", kind: "action", typ: "ActionTypeNormal", expected: "Normal"},
+		{name: "wrong kind", kind: "action", typ: "polling", err: true},
+		{name: "unknown", kind: "trigger", typ: "sometimes", err: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			typ, err := parseResourceType(tc.kind, tc.typ)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, typ)
+		})
+	}
+}
+
+func TestCollectInputNonInteractive(t *testing.T) {
+	schema := &sdk.IntegrationSchemaModel{Name: "Slack"}
+
+	meta, err := collectInput(context.Background(), "trigger", &AddResourceInput{
+		Name:        "New Message",
+		Description: `"Fires on new messages"`,
+		Type:        "sdkcore.TriggerTypePolling",
+	}, schema, NewOfflineGenerator())
+	require.NoError(t, err)
+	assert.Equal(t, "new_message", meta.FileName)
+	assert.Equal(t, "Fires on new messages", meta.Description)
+	assert.Equal(t, "sdkcore.TriggerTypePolling", meta.TypeName)
+	assert.Equal(t, "triggers.NewNewMessageTrigger", meta.Constructor)
+
+	meta, err = collectInput(context.Background(), "action", &AddResourceInput{Name: "Send", Description: "Sends"}, schema, NewOfflineGenerator())
+	require.NoError(t, err)
+	assert.Equal(t, "Normal", meta.Type)
+
+	_, err = collectInput(context.Background(), "trigger", &AddResourceInput{Name: "Send", Description: "Sends"}, schema, NewOfflineGenerator())
+	require.ErrorContains(t, err, "--type is required")
+
+	_, err = collectInput(context.Background(), "action", &AddResourceInput{}, schema, NewOfflineGenerator())
+	require.ErrorContains(t, err, "--name is required")
+
+	for _, name := range []string{"../../evil", "sub/dir", "2fa", "Send!", "   "} {
+		_, err = collectInput(context.Background(), "action", &AddResourceInput{Name: name, Description: "Sends"}, schema, NewOfflineGenerator())
+		require.ErrorContains(t, err, "invalid action name", name)
+	}
+}
+
+func TestRenderDocFile(t *testing.T) {
+	content, err := renderDocFile("action", []string{"actions/send_message.md", "actions/archive-channel.md", "actions/send_message.md"})
+	require.NoError(t, err)
+
+	expected := `// Code generated by wakflo from the Markdown files in this folder. DO NOT EDIT.
+
+package actions
+
+import _ "embed"
+
+//go:embed archive-channel.md
+var archiveChannelDocs string
+
+//go:embed send_message.md
+var sendMessageDocs string
+`
+	assert.Equal(t, expected, string(content))
+
+	// the names match the ones the templates reference
+	assert.Contains(t, renderResource(t, "send_message"), "&sendMessageDocs")
+	assert.Contains(t, renderResource(t, "archive-channel"), "&archiveChannelDocs")
+}
+
+func renderResource(t *testing.T, fileName string) string {
+	t.Helper()
+
+	changes, err := DefaultPack().render("action", &ActionTriggerMetadata{FileName: fileName, TypeName: "sdkcore.ActionTypeNormal"})
+	require.NoError(t, err)
+
+	return string(changes[0].content)
+}
+
+// TestGeneratedProjectCompiles scaffolds an integration, edits its resources
+// with every command and builds it against the go-sdk this module uses.
+func TestGeneratedProjectCompiles(t *testing.T) {
+	root := testutil.GoModule(t)
+
+	require.NoError(t, CreateIntegrationFolder(&CreateIntegrationProps{
+		IntegrationSchemaModel: sdk.IntegrationSchemaModel{Name: "Slack", Description: "Slack integration", Version: "0.0.1"},
+	}, &Project{Root: root, Out: io.Discard}))
+
+	p := &Project{Root: filepath.Join(root, "slack"), Out: io.Discard}
+	gen := NewOfflineGenerator()
+	require.NoError(t, HandleAddResource(context.Background(), "action", &AddResourceInput{Name: "Send Message", Description: "Sends"}, gen, p))
+	require.NoError(t, HandleAddResource(context.Background(), "action", &AddResourceInput{Name: "archive-channel", Description: "Archives"}, gen, p))
+	require.NoError(t, HandleAddResource(context.Background(), "action", &AddResourceInput{Name: "Pin", Description: "Pins"}, gen, p))
+	require.NoError(t, HandleAddResource(context.Background(), "trigger", &AddResourceInput{Name: "New Message", Description: "New", Type: "polling"}, gen, p))
+
+	_, err := HandleRenameResource("action", "Pin", "Pin Message", p)
+	require.NoError(t, err)
+
+	_, err = HandleRemoveResource("action", "archive-channel", p)
+	require.NoError(t, err)
+
+	build := exec.Command("go", "build", "./...")
+	build.Dir = root
+
+	out, err := build.CombinedOutput()
+	require.NoError(t, err, string(out))
+}
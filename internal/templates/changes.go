@@ -0,0 +1,248 @@
+package templates
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/afero"
+)
+
+// fileChange is a pending change to a project file, its path being relative to
+// the project root. A nil content removes the file. Generated files are marked
+// create: they only replace an existing file once the project allows it.
+type fileChange struct {
+	path    string
+	content []byte
+	summary string
+	create  bool
+}
+
+// changeSet is a group of file changes that is applied as a whole.
+type changeSet []fileChange
+
+// ErrInterrupted is returned when applying changes is stopped by an interrupt
+// signal. The changes already made are rolled back.
+var ErrInterrupted = errors.New("interrupted")
+
+// notifyInterrupt relays interrupt signals while changes are applied.
+var notifyInterrupt = func(c chan<- os.Signal) func() {
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+	return func() { signal.Stop(c) }
+}
+
+// apply performs every change in order. When one fails, or the process is
+// interrupted, the files changed and the folders created before are restored
+// so the project is left as it was.
+func (p *Project) apply(changes changeSet) error {
+	interrupts := make(chan os.Signal, 1)
+	defer notifyInterrupt(interrupts)()
+
+	tx := &transaction{fs: p.fs()}
+
+	for _, change := range changes {
+		change.path = p.path(change.path)
+
+		if err := tx.apply(change); err != nil {
+			tx.rollback()
+			return err
+		}
+
+		select {
+		case <-interrupts:
+			tx.rollback()
+			return ErrInterrupted
+		default:
+		}
+	}
+
+	return nil
+}
+
+// transaction records what is needed to undo the changes applied so far.
+type transaction struct {
+	fs      afero.Fs
+	files   []backup
+	folders []string
+}
+
+type backup struct {
+	path    string
+	content []byte
+	mode    os.FileMode
+	existed bool
+}
+
+func (tx *transaction) apply(change fileChange) error {
+	b := backup{path: change.path, mode: 0o644}
+
+	info, err := tx.fs.Stat(change.path)
+	switch {
+	case err == nil:
+		b.existed, b.mode = true, info.Mode().Perm()
+
+		if b.content, err = afero.ReadFile(tx.fs, change.path); err != nil {
+			return fmt.Errorf("failed to read '%s': %w", change.path, err)
+		}
+	case !errors.Is(err, os.ErrNotExist):
+		return fmt.Errorf("failed to read '%s': %w", change.path, err)
+	}
+
+	if change.content == nil {
+		if err := tx.fs.Remove(change.path); err != nil {
+			return fmt.Errorf("failed to remove '%s': %w", change.path, err)
+		}
+
+		tx.files = append(tx.files, b)
+
+		return nil
+	}
+
+	if err := tx.mkdirAll(filepath.Dir(change.path)); err != nil {
+		return fmt.Errorf("failed to create folder for '%s': %w", change.path, err)
+	}
+
+	if err := writeFileAtomic(tx.fs, change.path, change.content, b.mode); err != nil {
+		return fmt.Errorf("failed to write '%s': %w", change.path, err)
+	}
+
+	tx.files = append(tx.files, b)
+
+	return nil
+}
+
+// mkdirAll creates dir and its missing parents, remembering them for rollback.
+func (tx *transaction) mkdirAll(dir string) error {
+	var missing []string
+
+	for d := dir; ; d = filepath.Dir(d) {
+		if _, err := tx.fs.Stat(d); err == nil || filepath.Dir(d) == d {
+			break
+		}
+
+		missing = append(missing, d)
+	}
+
+	for i := len(missing) - 1; i >= 0; i-- {
+		if err := tx.fs.Mkdir(missing[i], os.ModePerm); err != nil && !errors.Is(err, os.ErrExist) {
+			return err
+		}
+
+		tx.folders = append(tx.folders, missing[i])
+	}
+
+	return nil
+}
+
+// rollback restores the files and removes the folders in reverse order.
+func (tx *transaction) rollback() {
+	for i := len(tx.files) - 1; i >= 0; i-- {
+		b := tx.files[i]
+		if b.existed {
+			_ = writeFileAtomic(tx.fs, b.path, b.content, b.mode)
+		} else {
+			_ = tx.fs.Remove(b.path)
+		}
+	}
+
+	for i := len(tx.folders) - 1; i >= 0; i-- {
+		_ = tx.fs.Remove(tx.folders[i])
+	}
+}
+
+// writeFileAtomic writes through a temporary file renamed over path, so that
+// path never holds partial content.
+func writeFileAtomic(fs afero.Fs, path string, content []byte, mode os.FileMode) error {
+	tmp, err := afero.TempFile(fs, filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	defer fs.Remove(tmp.Name()) //nolint:errcheck
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := fs.Chmod(tmp.Name(), mode); err != nil {
+		return err
+	}
+
+	return fs.Rename(tmp.Name(), path)
+}
+
+// diff writes a unified diff of the changes against the current files to Out.
+func (p *Project) diff(changes changeSet) error {
+	for _, change := range changes {
+		current, err := p.readFile(change.path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to read '%s': %w", change.path, err)
+		}
+
+		from, to := filepath.ToSlash(change.path), filepath.ToSlash(change.path)
+		if !filepath.IsAbs(change.path) {
+			from, to = "a/"+from, "b/"+to
+		}
+
+		if errors.Is(err, os.ErrNotExist) {
+			from = "/dev/null"
+		}
+
+		if change.content == nil {
+			to = "/dev/null"
+		}
+
+		text, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        splitLines(string(current)),
+			B:        splitLines(string(change.content)),
+			FromFile: from,
+			ToFile:   to,
+			Context:  3,
+		})
+		if err != nil {
+			return err
+		}
+
+		if _, err := io.WriteString(p.out(), text); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// splitLines splits s into lines that all end with a newline, as difflib expects.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	if !strings.HasSuffix(s, "\n") {
+		s += "\n"
+	}
+
+	return difflib.SplitLines(strings.TrimSuffix(s, "\n"))
+}
+
+// summaries describes what the changes do, one line per file.
+func (c changeSet) summaries() []string {
+	summaries := make([]string, 0, len(c))
+	for _, change := range c {
+		if change.summary != "" {
+			summaries = append(summaries, change.summary)
+		}
+	}
+
+	return summaries
+}
@@ -0,0 +1,94 @@
+package templates
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangeSetRollback(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "existing.txt")
+	removed := filepath.Join(dir, "removed.txt")
+	created := filepath.Join(dir, "actions", "nested", "created.txt")
+	require.NoError(t, os.WriteFile(existing, []byte("before"), 0o600))
+	require.NoError(t, os.WriteFile(removed, []byte("kept"), 0o644))
+
+	changes := changeSet{
+		{path: existing, content: []byte("after")},
+		{path: removed},
+		{path: created, content: []byte("new")},
+		{path: filepath.Join(existing, "file.txt"), content: []byte("fails")},
+	}
+	require.Error(t, NewProject(dir).apply(changes))
+
+	content, err := os.ReadFile(existing)
+	require.NoError(t, err)
+	assert.Equal(t, "before", string(content))
+
+	info, err := os.Stat(existing)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	content, err = os.ReadFile(removed)
+	require.NoError(t, err)
+	assert.Equal(t, "kept", string(content))
+
+	assert.NoDirExists(t, filepath.Join(dir, "actions"))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2, "no temporary file is left behind")
+}
+
+func TestChangeSetInterrupted(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first.txt")
+	second := filepath.Join(dir, "second.txt")
+
+	// an interrupt is pending as soon as the changes start being applied
+	notify := notifyInterrupt
+	t.Cleanup(func() { notifyInterrupt = notify })
+
+	notifyInterrupt = func(c chan<- os.Signal) func() {
+		c <- os.Interrupt
+		return func() {}
+	}
+
+	err := NewProject(dir).apply(changeSet{
+		{path: first, content: []byte("1")},
+		{path: second, content: []byte("2")},
+	})
+	require.ErrorIs(t, err, ErrInterrupted)
+
+	assert.NoFileExists(t, first)
+	assert.NoFileExists(t, second)
+}
+
+func TestProjectDryRun(t *testing.T) {
+	var out bytes.Buffer
+	p := newTestProject(t, testIntegrationFiles)
+	p.DryRun = true
+	p.Out = &out
+
+	require.NoError(t, HandleAddResource(context.Background(), "action", &AddResourceInput{Name: "Post Message", Description: "Posts"}, NewOfflineGenerator(), p))
+
+	assert.False(t, p.exists(filepath.Join("actions", "post_message.go")))
+	assert.False(t, p.exists(filepath.Join("actions", "doc.go")))
+	assert.Equal(t, testLibFile, readProjectFile(t, p, "lib.go"))
+
+	diff := out.String()
+	assert.Contains(t, diff, "--- /dev/null\n+++ b/actions/post_message.go\n")
+	assert.Contains(t, diff, "--- /dev/null\n+++ b/actions/doc.go\n")
+	assert.Contains(t, diff, "--- a/lib.go\n+++ b/lib.go\n")
+	assert.Contains(t, diff, "-\treturn []sdk.Action{}\n")
+	assert.Contains(t, diff, "+\t\tactions.NewPostMessageAction(),\n")
+	assert.Contains(t, diff, "--- a/README.md\n+++ b/README.md\n")
+	assert.Contains(t, diff, "Dry run: no file was changed.")
+	assert.NotContains(t, diff, "created successfully")
+}
@@ -0,0 +1,349 @@
+package templates
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/manifoldco/promptui"
+	"github.com/samber/lo"
+	"github.com/wakflo/go-sdk/sdk"
+)
+
+const flowsFolder = "flows"
+
+// FlowDefinition is the content of a flow file in the flows folder of an integration project.
+type FlowDefinition struct {
+	Flow    FlowMetadata `toml:"flow"`
+	Trigger FlowTrigger  `toml:"trigger"`
+	Steps   []FlowStep   `toml:"steps"`
+}
+
+type FlowMetadata struct {
+	Name        string `toml:"name"`
+	Description string `toml:"description,omitempty"`
+}
+
+// FlowTrigger references the trigger starting a flow.
+type FlowTrigger struct {
+	Integration string            `toml:"integration"`
+	Trigger     string            `toml:"trigger"`
+	Input       map[string]string `toml:"input"`
+}
+
+// FlowStep references an action run by a flow. Input values may refer to the
+// output of the trigger or earlier steps, e.g. "{{ trigger.output.email }}".
+type FlowStep struct {
+	ID          string            `toml:"id"`
+	Integration string            `toml:"integration"`
+	Action      string            `toml:"action"`
+	Input       map[string]string `toml:"input"`
+}
+
+// flowEntry registers a flow in flo.toml.
+type flowEntry struct {
+	Name string `toml:"name"`
+	Path string `toml:"path"`
+}
+
+type integrationFileModel struct {
+	Integration sdk.IntegrationSchemaModel `toml:"integration"`
+	Flows       []flowEntry                `toml:"flows"`
+}
+
+// AddFlowInput holds the values of a new flow that are known up front.
+// References have the form "integration/name", or just "name" for resources
+// of the current integration. Inputs have the form "<step id>.<key>=<value>",
+// with "trigger" as the step id of the trigger.
+type AddFlowInput struct {
+	Name        string
+	Description string
+	Trigger     string
+	Steps       []string
+	Inputs      []string
+	Interactive bool
+}
+
+// HandleAddFlow writes a flow definition into the flows folder of the
+// integration project p and registers it in flo.toml.
+func HandleAddFlow(input *AddFlowInput, p *Project) error {
+	project, err := readIntegrationFile(p, integrationFile)
+	if err != nil {
+		return err
+	}
+
+	current := ToPackageName(project.Integration.Name)
+
+	flow, err := collectFlowInput(p, input, current)
+	if err != nil {
+		return err
+	}
+
+	if err := validateFlow(p, flow, current); err != nil {
+		return err
+	}
+
+	fileName := formatFileName(flow.Flow.Name)
+	if fileName == "" || !filepath.IsLocal(fileName) {
+		return fmt.Errorf("invalid flow name '%s', it must not be empty or a path leaving the flows folder", flow.Flow.Name)
+	}
+
+	flowPath := filepath.Join(flowsFolder, fileName+".toml")
+
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(flow); err != nil {
+		return fmt.Errorf("failed to encode flow: %w", err)
+	}
+
+	changes := changeSet{{path: flowPath, content: buf.Bytes(), create: true}}
+
+	// A flow generated again is registered already
+	if !lo.ContainsBy(project.Flows, func(entry flowEntry) bool { return entry.Path == filepath.ToSlash(flowPath) }) {
+		registered, err := registerFlow(p, integrationFile, flow.Flow.Name, flowPath)
+		if err != nil {
+			return fmt.Errorf("failed to update '%s': %w", integrationFile, err)
+		}
+
+		changes = append(changes, fileChange{path: integrationFile, content: registered})
+	}
+
+	if err := p.commit(changes); err != nil {
+		return err
+	}
+
+	p.printf("Flow '%s' created successfully in '%s'.\n", flow.Flow.Name, flowPath)
+
+	return nil
+}
+
+func readIntegrationFile(p *Project, path string) (*integrationFileModel, error) {
+	data, err := p.readFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("not an integration project: missing '%s' file", path)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var model integrationFileModel
+	if err := toml.Unmarshal(data, &model); err != nil {
+		return nil, fmt.Errorf("failed to parse '%s' file: %w", path, err)
+	}
+
+	return &model, nil
+}
+
+func collectFlowInput(p *Project, input *AddFlowInput, current string) (*FlowDefinition, error) {
+	name := input.Name
+	if name == "" {
+		if !input.Interactive {
+			return nil, errors.New("--name is required when not running interactively")
+		}
+
+		var err error
+		name, err = (&promptui.Prompt{Label: "Enter Flow Name"}).Run()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get name: %w", err)
+		}
+	}
+
+	triggerRef := input.Trigger
+	if triggerRef == "" {
+		if !input.Interactive {
+			return nil, errors.New("--trigger is required when not running interactively")
+		}
+
+		ref, err := promptReference("trigger", listResources(p, "trigger"))
+		if err != nil {
+			return nil, err
+		}
+
+		triggerRef = ref
+	}
+
+	stepRefs := input.Steps
+	if len(stepRefs) == 0 && input.Interactive {
+		for {
+			ref, err := promptReference("action", listResources(p, "action"))
+			if err != nil {
+				return nil, err
+			}
+
+			stepRefs = append(stepRefs, ref)
+
+			if !confirm("Add another step") {
+				break
+			}
+		}
+	}
+
+	if len(stepRefs) == 0 {
+		return nil, errors.New("a flow needs at least one --step")
+	}
+
+	integration, trigger := parseReference(triggerRef, current)
+	flow := &FlowDefinition{
+		Flow:    FlowMetadata{Name: name, Description: input.Description},
+		Trigger: FlowTrigger{Integration: integration, Trigger: trigger, Input: map[string]string{}},
+	}
+
+	ids := map[string]int{}
+	for _, ref := range stepRefs {
+		integration, action := parseReference(ref, current)
+
+		id := action
+		if ids[action]++; ids[action] > 1 {
+			id = fmt.Sprintf("%s_%d", action, ids[action])
+		}
+
+		flow.Steps = append(flow.Steps, FlowStep{ID: id, Integration: integration, Action: action, Input: map[string]string{}})
+	}
+
+	for _, mapping := range input.Inputs {
+		if err := applyInputMapping(flow, mapping); err != nil {
+			return nil, err
+		}
+	}
+
+	return flow, nil
+}
+
+// parseReference splits "integration/name" and normalises name to the file
+// name of the resource. References without an integration belong to current.
+func parseReference(ref, current string) (string, string) {
+	integration, name, found := strings.Cut(ref, "/")
+	if !found {
+		integration, name = current, ref
+	}
+
+	return ToPackageName(integration), formatFileName(strings.TrimSpace(name))
+}
+
+func applyInputMapping(flow *FlowDefinition, mapping string) error {
+	target, value, found := strings.Cut(mapping, "=")
+	id, key, hasKey := strings.Cut(target, ".")
+
+	if !found || !hasKey || key == "" {
+		return fmt.Errorf("invalid input mapping '%s', expected '<step id>.<key>=<value>'", mapping)
+	}
+
+	if id == "trigger" {
+		flow.Trigger.Input[key] = value
+		return nil
+	}
+
+	for i := range flow.Steps {
+		if flow.Steps[i].ID == id {
+			flow.Steps[i].Input[key] = value
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid input mapping '%s': no step with id '%s'", mapping, id)
+}
+
+// validateFlow checks that every referenced trigger and action exists, either
+// in the current project or in an integration project next to it.
+func validateFlow(p *Project, flow *FlowDefinition, current string) error {
+	var missing []string
+
+	if !resourceExists(p, "trigger", flow.Trigger.Integration, flow.Trigger.Trigger, current) {
+		missing = append(missing, fmt.Sprintf("trigger '%s/%s'", flow.Trigger.Integration, flow.Trigger.Trigger))
+	}
+
+	for _, step := range flow.Steps {
+		if !resourceExists(p, "action", step.Integration, step.Action, current) {
+			missing = append(missing, fmt.Sprintf("action '%s/%s' (step '%s')", step.Integration, step.Action, step.ID))
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("flow references resources that do not exist: %s", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+func resourceExists(p *Project, kind, integration, name, current string) bool {
+	dir := "."
+	if integration != current {
+		dir = filepath.Join("..", integration)
+	}
+
+	return p.exists(filepath.Join(dir, kind+"s", name+".go"))
+}
+
+// listResources returns the file names of the actions or triggers of the project.
+func listResources(p *Project, kind string) []string {
+	matches, _ := p.glob(filepath.Join(kind+"s", "*.go"))
+
+	return lo.FilterMap(matches, func(match string, _ int) (string, bool) {
+		name := strings.TrimSuffix(filepath.Base(match), ".go")
+		return name, name != "doc" && !strings.HasSuffix(name, "_test")
+	})
+}
+
+func promptReference(kind string, local []string) (string, error) {
+	const other = "other integration..."
+
+	if len(local) > 0 {
+		_, ref, err := (&promptui.Select{
+			Label: fmt.Sprintf("Select %s", strings.Title(kind)),
+			Items: append(local, other),
+		}).Run()
+		if err != nil {
+			return "", fmt.Errorf("failed to select %s: %w", kind, err)
+		}
+
+		if ref != other {
+			return ref, nil
+		}
+	}
+
+	ref, err := (&promptui.Prompt{Label: fmt.Sprintf("Enter %s (integration/name)", strings.Title(kind))}).Run()
+	if err != nil {
+		return "", fmt.Errorf("failed to get %s: %w", kind, err)
+	}
+
+	return ref, nil
+}
+
+func confirm(label string) bool {
+	_, err := (&promptui.Prompt{Label: label, IsConfirm: true}).Run()
+	return err == nil
+}
+
+// registerFlow returns flo.toml with the flow appended to its [[flows]] list,
+// leaving the rest of the file untouched.
+func registerFlow(p *Project, path, name, flowPath string) ([]byte, error) {
+	data, err := p.readFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	content := string(data)
+	if !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(content + "\n")
+
+	enc := toml.NewEncoder(&buf)
+	enc.Indent = ""
+
+	entry := struct {
+		Flows []flowEntry `toml:"flows"`
+	}{Flows: []flowEntry{{Name: name, Path: filepath.ToSlash(flowPath)}}}
+
+	if err := enc.Encode(entry); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
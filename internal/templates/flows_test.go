@@ -0,0 +1,132 @@
+package templates
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestProject returns an in-memory integration project called "slack"
+// next to a "gmail" project, with the given extra files.
+func newTestProject(t *testing.T, extra map[string]string) *Project {
+	t.Helper()
+
+	p := NewMemProject("/integrations/slack")
+	files := map[string]string{
+		"flo.toml":                       "[integration]\nname = \"Slack\"\n",
+		"triggers/new_message.go":        "package triggers\n",
+		"actions/send_message.go":        "package actions\n",
+		"../gmail/actions/send_email.go": "package actions\n",
+	}
+
+	maps.Copy(files, extra)
+
+	for path, content := range files {
+		require.NoError(t, afero.WriteFile(p.FS, p.path(path), []byte(content), 0o644))
+	}
+
+	return p
+}
+
+// readProjectFile returns the content of a file of p.
+func readProjectFile(t *testing.T, p *Project, name string) string {
+	t.Helper()
+
+	content, err := p.readFile(name)
+	require.NoError(t, err)
+
+	return string(content)
+}
+
+func TestHandleAddFlow(t *testing.T) {
+	p := newTestProject(t, nil)
+
+	err := HandleAddFlow(&AddFlowInput{
+		Name:    "Notify Team",
+		Trigger: "New Message",
+		Steps:   []string{"send_message", "gmail/send_email", "send_message"},
+		Inputs: []string{
+			"trigger.channel=general",
+			"send_email.to={{ trigger.output.email }}",
+			"send_message_2.text=done",
+		},
+	}, p)
+	require.NoError(t, err)
+
+	var flow FlowDefinition
+	_, err = toml.Decode(readProjectFile(t, p, filepath.Join("flows", "notify_team.toml")), &flow)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Notify Team", flow.Flow.Name)
+	assert.Equal(t, FlowTrigger{Integration: "slack", Trigger: "new_message", Input: map[string]string{"channel": "general"}}, flow.Trigger)
+	require.Len(t, flow.Steps, 3)
+	assert.Equal(t, "send_message", flow.Steps[0].ID)
+	assert.Equal(t, FlowStep{ID: "send_email", Integration: "gmail", Action: "send_email", Input: map[string]string{"to": "{{ trigger.output.email }}"}}, flow.Steps[1])
+	assert.Equal(t, "send_message_2", flow.Steps[2].ID)
+	assert.Equal(t, "done", flow.Steps[2].Input["text"])
+
+	project, err := readIntegrationFile(p, integrationFile)
+	require.NoError(t, err)
+	assert.Equal(t, "Slack", project.Integration.Name)
+	assert.Equal(t, []flowEntry{{Name: "Notify Team", Path: "flows/notify_team.toml"}}, project.Flows)
+
+	err = HandleAddFlow(&AddFlowInput{Name: "Notify Team", Trigger: "new_message", Steps: []string{"send_message"}}, p)
+	require.ErrorIs(t, err, os.ErrExist)
+
+	p.Force = true
+	require.NoError(t, HandleAddFlow(&AddFlowInput{Name: "Notify Team", Trigger: "new_message", Steps: []string{"send_message"}}, p))
+
+	project, err = readIntegrationFile(p, integrationFile)
+	require.NoError(t, err)
+	assert.Len(t, project.Flows, 1, "a flow generated again is not registered twice")
+}
+
+func TestHandleAddFlowValidation(t *testing.T) {
+	p := newTestProject(t, nil)
+
+	testCases := []struct {
+		name  string
+		input *AddFlowInput
+		err   string
+	}{
+		{
+			name:  "missing action",
+			input: &AddFlowInput{Name: "a", Trigger: "new_message", Steps: []string{"gmail/read_email"}},
+			err:   "action 'gmail/read_email'",
+		},
+		{
+			name:  "missing integration",
+			input: &AddFlowInput{Name: "b", Trigger: "github/new_issue", Steps: []string{"send_message"}},
+			err:   "trigger 'github/new_issue'",
+		},
+		{
+			name:  "no steps",
+			input: &AddFlowInput{Name: "c", Trigger: "new_message"},
+			err:   "at least one --step",
+		},
+		{
+			name:  "unknown step in mapping",
+			input: &AddFlowInput{Name: "d", Trigger: "new_message", Steps: []string{"send_message"}, Inputs: []string{"nope.key=value"}},
+			err:   "no step with id 'nope'",
+		},
+		{
+			name:  "name leaving the flows folder",
+			input: &AddFlowInput{Name: "../../escape", Trigger: "new_message", Steps: []string{"send_message"}},
+			err:   "invalid flow name",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.ErrorContains(t, HandleAddFlow(tc.input, p), tc.err)
+		})
+	}
+
+	assert.False(t, p.exists("flows"))
+}
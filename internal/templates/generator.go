@@ -0,0 +1,167 @@
+package templates
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/wakflo/go-sdk/client"
+)
+
+// PlaceholderIcon is used for new integrations when no icon can be searched for.
+const PlaceholderIcon = "mdi:puzzle-outline"
+
+// BundledCategories is the category list used when the API cannot be reached.
+var BundledCategories = []string{
+	"app",
+	"ai",
+	"analytics",
+	"communication",
+	"core",
+	"crm",
+	"database",
+	"developer-tools",
+	"e-commerce",
+	"email",
+	"finance",
+	"forms",
+	"human-resources",
+	"marketing",
+	"productivity",
+	"project-management",
+	"sales",
+	"social-media",
+	"storage",
+	"support",
+}
+
+// Generator supplies the suggestions scaffolding commands offer: descriptions,
+// documentation, icons and categories.
+type Generator interface {
+	Description(ctx context.Context, kind, prompt string) (string, error)
+	Documentation(ctx context.Context, kind, prompt string) (string, error)
+	Icons(ctx context.Context, name string) ([]string, error)
+	Categories(ctx context.Context) ([]string, error)
+}
+
+// NewAPIGenerator returns a generator backed by the Wakflo API.
+func NewAPIGenerator(floClient *client.Client) Generator {
+	return &apiGenerator{client: floClient}
+}
+
+type apiGenerator struct {
+	client *client.Client
+}
+
+func (g *apiGenerator) Description(ctx context.Context, kind, prompt string) (string, error) {
+	resp, err := g.client.Rest.GenerateDescription(ctx, client.RestGenerateDescriptionRequest{
+		Prompt: prompt,
+		Type:   kind,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Trim(resp.Data, `"'`), nil
+}
+
+func (g *apiGenerator) Documentation(ctx context.Context, kind, prompt string) (string, error) {
+	resp, err := g.client.Rest.GenerateDocumentation(ctx, client.RestGenerateDocumentationRequest{
+		Prompt: prompt,
+		Type:   kind,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return resp.Data, nil
+}
+
+func (g *apiGenerator) Icons(ctx context.Context, name string) ([]string, error) {
+	resp, err := g.client.Rest.SearchIcon(ctx, client.RestSearchIconRequest{
+		Name: name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Icons, nil
+}
+
+func (g *apiGenerator) Categories(ctx context.Context) ([]string, error) {
+	resp, err := g.client.Rest.ListCategories(ctx, client.RestListCategoriesRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Keys, nil
+}
+
+// NewOfflineGenerator returns a generator that works without network access,
+// using the prompt as description, no documentation, the placeholder icon and
+// the bundled categories.
+func NewOfflineGenerator() Generator {
+	return offlineGenerator{}
+}
+
+type offlineGenerator struct{}
+
+func (offlineGenerator) Description(_ context.Context, _, prompt string) (string, error) {
+	return prompt, nil
+}
+
+func (offlineGenerator) Documentation(context.Context, string, string) (string, error) {
+	return "", nil
+}
+
+func (offlineGenerator) Icons(context.Context, string) ([]string, error) {
+	return []string{PlaceholderIcon}, nil
+}
+
+func (offlineGenerator) Categories(context.Context) ([]string, error) {
+	return BundledCategories, nil
+}
+
+// NewFallbackGenerator returns a generator that uses primary until one of its
+// calls fails and the offline generator from then on, reporting the switch to warn.
+func NewFallbackGenerator(primary Generator, warn io.Writer) Generator {
+	return &fallbackGenerator{primary: primary, offline: NewOfflineGenerator(), warn: warn}
+}
+
+type fallbackGenerator struct {
+	primary Generator
+	offline Generator
+	warn    io.Writer
+	failed  bool
+}
+
+func (g *fallbackGenerator) Description(ctx context.Context, kind, prompt string) (string, error) {
+	return fallback(g, func(gen Generator) (string, error) { return gen.Description(ctx, kind, prompt) })
+}
+
+func (g *fallbackGenerator) Documentation(ctx context.Context, kind, prompt string) (string, error) {
+	return fallback(g, func(gen Generator) (string, error) { return gen.Documentation(ctx, kind, prompt) })
+}
+
+func (g *fallbackGenerator) Icons(ctx context.Context, name string) ([]string, error) {
+	return fallback(g, func(gen Generator) ([]string, error) { return gen.Icons(ctx, name) })
+}
+
+func (g *fallbackGenerator) Categories(ctx context.Context) ([]string, error) {
+	return fallback(g, func(gen Generator) ([]string, error) { return gen.Categories(ctx) })
+}
+
+func fallback[T any](g *fallbackGenerator, call func(gen Generator) (T, error)) (T, error) {
+	if !g.failed {
+		value, err := call(g.primary)
+		if err == nil {
+			return value, nil
+		}
+
+		g.failed = true
+		fmt.Fprintf(g.warn, "Warning: Wakflo API unavailable (%v), continuing offline with local defaults.\n", err)
+	}
+
+	return call(g.offline)
+}
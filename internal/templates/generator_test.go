@@ -0,0 +1,63 @@
+package templates
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type failingGenerator struct {
+	calls int
+}
+
+func (g *failingGenerator) Description(context.Context, string, string) (string, error) {
+	g.calls++
+	return "", errors.New("dial tcp: connection refused")
+}
+
+func (g *failingGenerator) Documentation(context.Context, string, string) (string, error) {
+	g.calls++
+	return "", errors.New("dial tcp: connection refused")
+}
+
+func (g *failingGenerator) Icons(context.Context, string) ([]string, error) {
+	g.calls++
+	return nil, errors.New("dial tcp: connection refused")
+}
+
+func (g *failingGenerator) Categories(context.Context) ([]string, error) {
+	g.calls++
+	return nil, errors.New("dial tcp: connection refused")
+}
+
+func TestFallbackGenerator(t *testing.T) {
+	primary := &failingGenerator{}
+	warn := bytes.NewBufferString("")
+	gen := NewFallbackGenerator(primary, warn)
+	ctx := context.Background()
+
+	description, err := gen.Description(ctx, "integration", "Slack integration")
+	require.NoError(t, err)
+	assert.Equal(t, "Slack integration", description)
+	assert.Contains(t, warn.String(), "connection refused")
+
+	icons, err := gen.Icons(ctx, "Slack")
+	require.NoError(t, err)
+	assert.Equal(t, []string{PlaceholderIcon}, icons)
+
+	categories, err := gen.Categories(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, BundledCategories, categories)
+
+	docs, err := gen.Documentation(ctx, "integration", "Slack integration")
+	require.NoError(t, err)
+	assert.Empty(t, docs)
+
+	// the API is only tried once and the warning only printed once
+	assert.Equal(t, 1, primary.calls)
+	assert.Equal(t, 1, bytes.Count(warn.Bytes(), []byte("Warning")))
+}
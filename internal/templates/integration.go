@@ -2,6 +2,7 @@ package templates
 
 import (
 	"fmt"
+	"go/token"
 	"os"
 	"path/filepath"
 	"strings"
@@ -14,94 +15,33 @@ type CreateIntegrationProps struct {
 	Docs string `json:"name" toml:"name" yaml:"name"`
 }
 
-func CreateIntegrationFolder(meta *CreateIntegrationProps) error {
-	// Generate folder structure
+// CreateIntegrationFolder creates the folder of a new integration inside the
+// root of p.
+func CreateIntegrationFolder(meta *CreateIntegrationProps, p *Project) error {
 	folderName := strings.ReplaceAll(strings.ToLower(meta.Name), " ", "")
-	if err := os.Mkdir(folderName, os.ModePerm); err != nil {
-		return fmt.Errorf("failed to create folder '%s': %w", folderName, err)
+	if !filepath.IsLocal(folderName) || !token.IsIdentifier(ToPackageName(meta.Name)) {
+		return fmt.Errorf("invalid integration name '%s', it must start with a letter and only contain letters, digits, spaces and '_'", meta.Name)
 	}
 
-	// Populate the folder with boilerplate files
-	files := map[string]string{
-		"lib.go":        libGoTemplate,
-		"README.md":     readmeTemplate,
-		integrationFile: integrationTomlTemplate,
+	if p.exists(folderName) && !p.Force && !p.Interactive {
+		return fmt.Errorf("failed to create folder '%s' (use --force to overwrite its files): %w", p.path(folderName), os.ErrExist)
 	}
 
-	for fileName, content := range files {
-		filePath := filepath.Join(folderName, fileName)
-		if err := WriteTemplateToFile(filePath, content, meta); err != nil {
-			return fmt.Errorf("failed to create file '%s': %w", filePath, err)
-		}
+	// Populate the folder with boilerplate files
+	changes, err := p.pack().render("integration", meta)
+	if err != nil {
+		return fmt.Errorf("failed to create integration files: %w", err)
 	}
 
-	fmt.Printf("Integration '%s' created successfully in folder '%s'.\n", meta.Name, folderName)
-	return nil
-}
-
-// Templates for the integration files
-
-const libGoTemplate = `package {{ .Name | toPackageName }}
-
-import (
-	_ "embed"
-
-	"github.com/wakflo/go-sdk/sdk"
-)
-
-//go:embed README.md
-var ReadME string
-
-//go:embed flo.toml
-var Flow string
-
-var Integration = sdk.Register(New{{ .Name | toPascal }}(), Flow, ReadME)
-
-type {{ .Name | toPascal }} struct{}
-
-func (n *{{ .Name | toPascal }}) Auth() *sdk.Auth {
-	return &sdk.Auth{
-		Required: false,
+	for i := range changes {
+		changes[i].path = filepath.Join(folderName, changes[i].path)
 	}
-}
 
-func (n *{{ .Name | toPascal }}) Triggers() []sdk.Trigger {
-	return []sdk.Trigger{}
-}
+	if err := p.commit(changes); err != nil {
+		return err
+	}
 
-func (n *{{ .Name | toPascal }}) Actions() []sdk.Action {
-	return []sdk.Action{}
-}
+	p.printf("Integration '%s' created successfully in folder '%s'.\n", meta.Name, p.path(folderName))
 
-func New{{ .Name | toPascal }}() sdk.Integration {
-	return &{{ .Name | toPascal }}{}
+	return nil
 }
-`
-
-const readmeTemplate = `# {{ .Name }} Integration
-
-## Description
-
-{{ .Description }}
-
-{{ .Docs }}
-
-## Categories
-
-{{ range .Categories }}- {{ . }}
-{{ end }}
-
-## Authors
-
-{{ range .Authors }}- {{ . }}
-{{ end }}
-`
-
-const integrationTomlTemplate = `[integration]
-name = "{{ .Name }}"
-description = """{{ .Description }}"""
-version = "{{ .Version }}"
-icon = "{{ .Icon }}"
-categories = [{{ range $index, $element := .Categories }}{{ if $index }}, {{ end }}"{{ $element }}"{{ end }}]
-authors = [{{ range $index, $element := .Authors }}{{ if $index }}, {{ end }}"{{ $element }}"{{ end }}]
-`
@@ -0,0 +1,618 @@
+package templates
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+var (
+	errNotRegistered = errors.New("not registered")
+	errRegistered    = errors.New("already registered")
+)
+
+// libEdit is a pending change to the Go file that registers the actions or
+// triggers of an integration.
+type libEdit struct {
+	path    string
+	content []byte
+}
+
+// planLibEdit locates the Actions() or Triggers() method of the integration
+// type in dir, appends the constructor of meta to the slice literal it returns
+// and adds the actions or triggers import if missing. The method may live in
+// any file of the package and may return the literal directly or through a
+// variable. When there is no single, unambiguous literal to extend, planLibEdit
+// refuses instead of guessing.
+func planLibEdit(p *Project, dir string, meta *ActionTriggerMetadata) (*libEdit, error) {
+	reg, err := locateRegistration(p, dir, meta.Kind)
+	if err != nil {
+		return nil, err
+	}
+
+	if reg.err != nil {
+		return nil, fmt.Errorf("cannot safely register %s(): %w; add it to %s() manually", meta.Constructor, reg.err, reg.method)
+	}
+
+	subPackage, ctorName, _ := strings.Cut(meta.Constructor, ".")
+
+	importPath, err := reg.pkg.subPackageImportPath(reg.file, subPackage)
+	if err != nil {
+		return nil, err
+	}
+
+	localName, imported, err := importName(reg.file.ast, importPath, subPackage)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, elt := range reg.lit.Elts {
+		if isCallTo(elt, localName, ctorName) {
+			return nil, fmt.Errorf("%s.%s() is %w in %s()", localName, ctorName, errRegistered, reg.method)
+		}
+	}
+
+	edits := []textEdit{reg.pkg.appendElementEdit(reg.lit, reg.file.src, fmt.Sprintf("%s.%s()", localName, ctorName))}
+
+	if !imported {
+		edits = append(edits, reg.pkg.addImportEdit(reg.file.ast, importPath))
+	}
+
+	return reg.edit(edits)
+}
+
+// planLibRemoval removes the constructor of meta from the Actions() or
+// Triggers() method, along with its import once nothing else uses it.
+func planLibRemoval(p *Project, dir string, meta *ActionTriggerMetadata) (*libEdit, error) {
+	reg, err := locateRegistration(p, dir, meta.Kind)
+	if err != nil {
+		return nil, err
+	}
+
+	if reg.err != nil {
+		return nil, fmt.Errorf("cannot safely unregister %s(): %w; remove it from %s() manually", meta.Constructor, reg.err, reg.method)
+	}
+
+	_, ctorName, _ := strings.Cut(meta.Constructor, ".")
+
+	index := slices.IndexFunc(reg.lit.Elts, func(elt ast.Expr) bool {
+		return isCallTo(elt, "", ctorName)
+	})
+	if index == -1 {
+		return nil, fmt.Errorf("%s() is %w in %s()", ctorName, errNotRegistered, reg.method)
+	}
+
+	localName := reg.lit.Elts[index].(*ast.CallExpr).Fun.(*ast.SelectorExpr).X.(*ast.Ident).Name
+	edits := []textEdit{reg.pkg.removeElementEdit(reg.lit, index, reg.file.src)}
+
+	// the removed element is the only remaining use of the import
+	if countReferences(reg.file.ast, localName) == 1 {
+		if spec := importSpecNamed(reg.file.ast, localName); spec != nil {
+			edits = append(edits, reg.pkg.removeImportEdit(reg.file.ast, spec, reg.file.src))
+		}
+	}
+
+	return reg.edit(edits)
+}
+
+// planLibRename points the registration of oldMeta at the constructor of newMeta.
+func planLibRename(p *Project, dir string, oldMeta, newMeta *ActionTriggerMetadata) (*libEdit, error) {
+	reg, err := locateRegistration(p, dir, oldMeta.Kind)
+	if err != nil {
+		return nil, err
+	}
+
+	if reg.err != nil {
+		return nil, fmt.Errorf("cannot safely rename %s(): %w; rename it in %s() manually", oldMeta.Constructor, reg.err, reg.method)
+	}
+
+	_, oldCtor, _ := strings.Cut(oldMeta.Constructor, ".")
+	_, newCtor, _ := strings.Cut(newMeta.Constructor, ".")
+
+	index := slices.IndexFunc(reg.lit.Elts, func(elt ast.Expr) bool {
+		return isCallTo(elt, "", oldCtor)
+	})
+	if index == -1 {
+		return nil, fmt.Errorf("%s() is %w in %s()", oldCtor, errNotRegistered, reg.method)
+	}
+
+	sel := reg.lit.Elts[index].(*ast.CallExpr).Fun.(*ast.SelectorExpr).Sel
+	offset := reg.pkg.fset.Position(sel.Pos()).Offset
+
+	return reg.edit([]textEdit{{offset: offset, end: offset + len(oldCtor), text: newCtor}})
+}
+
+// registration is the slice literal returned by the Actions() or Triggers()
+// method of an integration. err explains why it cannot be edited safely.
+type registration struct {
+	pkg    *parsedPackage
+	method string
+	lit    *ast.CompositeLit
+	file   *parsedFile
+	err    error
+}
+
+func locateRegistration(p *Project, dir, kind string) (*registration, error) {
+	method, elem := "Actions", "Action"
+	if kind == "trigger" {
+		method, elem = "Triggers", "Trigger"
+	}
+
+	pkg, err := parsePackage(p, dir, false)
+	if err != nil {
+		return nil, err
+	}
+
+	fn, err := pkg.findMethod(method, elem)
+	if err != nil {
+		return nil, err
+	}
+
+	reg := &registration{pkg: pkg, method: method}
+	reg.lit, reg.file, reg.err = pkg.returnedLiteral(fn)
+
+	return reg, nil
+}
+
+func (r *registration) edit(edits []textEdit) (*libEdit, error) {
+	content, err := format.Source(applyTextEdits(r.file.src, edits))
+	if err != nil {
+		return nil, fmt.Errorf("failed to format '%s': %w", r.file.path, err)
+	}
+
+	return &libEdit{path: r.file.path, content: content}, nil
+}
+
+type parsedFile struct {
+	path string
+	src  []byte
+	ast  *ast.File
+}
+
+type parsedPackage struct {
+	project *Project
+	dir     string
+	fset    *token.FileSet
+	files   []*parsedFile
+}
+
+// parsePackage parses the Go files of dir, with its _test.go files when tests
+// is set.
+func parsePackage(p *Project, dir string, tests bool) (*parsedPackage, error) {
+	matches, err := p.glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	pkg := &parsedPackage{project: p, dir: dir, fset: token.NewFileSet()}
+
+	for _, match := range matches {
+		if !tests && strings.HasSuffix(match, "_test.go") {
+			continue
+		}
+
+		src, err := p.readFile(match)
+		if err != nil {
+			return nil, err
+		}
+
+		file, err := parser.ParseFile(pkg.fset, match, src, parser.ParseComments)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse '%s': %w", match, err)
+		}
+
+		pkg.files = append(pkg.files, &parsedFile{path: match, src: src, ast: file})
+	}
+
+	if len(pkg.files) == 0 {
+		return nil, fmt.Errorf("no Go files found in '%s'", dir)
+	}
+
+	return pkg, nil
+}
+
+type foundMethod struct {
+	decl *ast.FuncDecl
+	file *parsedFile
+}
+
+// findMethod returns the method called name that returns []sdk.<elem>.
+func (p *parsedPackage) findMethod(name, elem string) (*foundMethod, error) {
+	var found []*foundMethod
+
+	for _, file := range p.files {
+		for _, decl := range file.ast.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || fn.Name.Name != name || fn.Body == nil {
+				continue
+			}
+
+			if fn.Type.Results == nil || len(fn.Type.Results.List) != 1 || !isSliceOf(fn.Type.Results.List[0].Type, elem) {
+				continue
+			}
+
+			found = append(found, &foundMethod{decl: fn, file: file})
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("could not find the %s() method returning []sdk.%s in '%s'", name, elem, p.dir)
+	case 1:
+		return found[0], nil
+	default:
+		var where []string
+		for _, f := range found {
+			where = append(where, p.fset.Position(f.decl.Pos()).String())
+		}
+
+		return nil, fmt.Errorf("found several %s() methods (%s), cannot tell which one belongs to the integration", name, strings.Join(where, ", "))
+	}
+}
+
+// returnedLiteral finds the slice literal the method returns, either directly
+// or through a variable assigned exactly once.
+func (p *parsedPackage) returnedLiteral(fn *foundMethod) (*ast.CompositeLit, *parsedFile, error) {
+	var returns []*ast.ReturnStmt
+
+	ast.Inspect(fn.decl.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			returns = append(returns, n)
+		}
+
+		return true
+	})
+
+	if len(returns) != 1 || len(returns[0].Results) != 1 {
+		return nil, nil, fmt.Errorf("%s() must have exactly one return statement", fn.decl.Name.Name)
+	}
+
+	switch result := returns[0].Results[0].(type) {
+	case *ast.CompositeLit:
+		return result, fn.file, nil
+	case *ast.Ident:
+		return p.assignedLiteral(fn, result.Name)
+	default:
+		return nil, nil, fmt.Errorf("%s() returns an expression that is neither a slice literal nor a variable", fn.decl.Name.Name)
+	}
+}
+
+func (p *parsedPackage) assignedLiteral(fn *foundMethod, name string) (*ast.CompositeLit, *parsedFile, error) {
+	var (
+		values []ast.Expr
+		file   = fn.file
+	)
+
+	ast.Inspect(fn.decl.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			for i, lhs := range n.Lhs {
+				if ident, ok := lhs.(*ast.Ident); ok && ident.Name == name && len(n.Rhs) == len(n.Lhs) {
+					values = append(values, n.Rhs[i])
+				}
+			}
+		case *ast.ValueSpec:
+			values = append(values, specValues(n, name)...)
+		}
+
+		return true
+	})
+
+	// fall back to a package-level variable
+	if len(values) == 0 {
+		for _, f := range p.files {
+			for _, decl := range f.ast.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.VAR {
+					continue
+				}
+
+				for _, spec := range gen.Specs {
+					if found := specValues(spec.(*ast.ValueSpec), name); len(found) > 0 {
+						values = append(values, found...)
+						file = f
+					}
+				}
+			}
+		}
+	}
+
+	if len(values) != 1 {
+		return nil, nil, fmt.Errorf("variable '%s' is not assigned exactly once", name)
+	}
+
+	lit, ok := values[0].(*ast.CompositeLit)
+	if !ok {
+		return nil, nil, fmt.Errorf("variable '%s' is not initialised with a slice literal", name)
+	}
+
+	return lit, file, nil
+}
+
+func specValues(spec *ast.ValueSpec, name string) []ast.Expr {
+	var values []ast.Expr
+
+	for i, ident := range spec.Names {
+		if ident.Name == name && i < len(spec.Values) {
+			values = append(values, spec.Values[i])
+		}
+	}
+
+	return values
+}
+
+// subPackageImportPath returns the import path of the sub-package (e.g.
+// "actions") of the integration, taken from an existing import or from go.mod.
+func (p *parsedPackage) subPackageImportPath(file *parsedFile, sub string) (string, error) {
+	for _, f := range p.files {
+		for _, spec := range f.ast.Imports {
+			importPath, _ := strconv.Unquote(spec.Path.Value)
+			if path.Base(importPath) == sub && !strings.Contains(importPath, "wakflo/go-sdk") {
+				return importPath, nil
+			}
+		}
+	}
+
+	abs, err := filepath.Abs(p.project.path(p.dir))
+	if err != nil {
+		return "", err
+	}
+
+	root, module, err := findModule(p.project.fs(), abs)
+	if err != nil {
+		return "", fmt.Errorf("cannot determine the import path of '%s' for '%s': %w", sub, file.path, err)
+	}
+
+	rel, err := filepath.Rel(root, abs)
+	if err != nil {
+		return "", err
+	}
+
+	return path.Join(module, filepath.ToSlash(rel), sub), nil
+}
+
+// findModule walks up from dir to the closest go.mod and returns its directory and module path.
+func findModule(fsys afero.Fs, dir string) (string, string, error) {
+	for {
+		data, err := afero.ReadFile(fsys, filepath.Join(dir, "go.mod"))
+		if err == nil {
+			scanner := bufio.NewScanner(bytes.NewReader(data))
+			for scanner.Scan() {
+				if module, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "module "); ok {
+					return dir, strings.Trim(strings.TrimSpace(module), `"`), nil
+				}
+			}
+
+			return "", "", fmt.Errorf("no module directive in '%s'", filepath.Join(dir, "go.mod"))
+		}
+
+		if !errors.Is(err, os.ErrNotExist) {
+			return "", "", err
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", "", errors.New("no go.mod found")
+		}
+
+		dir = parent
+	}
+}
+
+// importName returns the name importPath is used under in file and whether it is
+// imported already. It refuses when name is taken by another import.
+func importName(file *ast.File, importPath, name string) (string, bool, error) {
+	for _, spec := range file.Imports {
+		specPath, _ := strconv.Unquote(spec.Path.Value)
+		local := path.Base(specPath)
+
+		if spec.Name != nil {
+			local = spec.Name.Name
+		}
+
+		if specPath == importPath {
+			return local, true, nil
+		}
+
+		if local == name {
+			return "", false, fmt.Errorf("cannot import '%s': the name '%s' is already used by '%s'", importPath, name, specPath)
+		}
+	}
+
+	return name, false, nil
+}
+
+func isSliceOf(expr ast.Expr, elem string) bool {
+	arr, ok := expr.(*ast.ArrayType)
+	if !ok || arr.Len != nil {
+		return false
+	}
+
+	sel, ok := arr.Elt.(*ast.SelectorExpr)
+
+	return ok && sel.Sel.Name == elem
+}
+
+// isCallTo reports whether expr calls pkg.fn(), any pkg matching when empty.
+func isCallTo(expr ast.Expr, pkg, fn string) bool {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+
+	ident, ok := sel.X.(*ast.Ident)
+
+	return ok && (pkg == "" || ident.Name == pkg) && sel.Sel.Name == fn
+}
+
+func countReferences(file *ast.File, name string) int {
+	count := 0
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if ident, ok := sel.X.(*ast.Ident); ok && ident.Name == name {
+				count++
+			}
+		}
+
+		return true
+	})
+
+	return count
+}
+
+func importSpecNamed(file *ast.File, name string) *ast.ImportSpec {
+	for _, spec := range file.Imports {
+		specPath, _ := strconv.Unquote(spec.Path.Value)
+
+		local := path.Base(specPath)
+		if spec.Name != nil {
+			local = spec.Name.Name
+		}
+
+		if local == name {
+			return spec
+		}
+	}
+
+	return nil
+}
+
+// textEdit replaces src[offset:end] with text. An end of zero inserts text at offset.
+type textEdit struct {
+	offset int
+	end    int
+	text   string
+}
+
+// appendElementEdit inserts elem as the last element of lit.
+func (p *parsedPackage) appendElementEdit(lit *ast.CompositeLit, src []byte, elem string) textEdit {
+	rbrace := p.fset.Position(lit.Rbrace).Offset
+
+	prefix := ""
+	if len(lit.Elts) > 0 {
+		last := p.fset.Position(lit.Elts[len(lit.Elts)-1].End()).Offset
+		if !strings.HasPrefix(strings.TrimSpace(string(src[last:rbrace])), ",") {
+			prefix = ","
+		}
+	}
+
+	return textEdit{offset: rbrace, text: prefix + "\n" + elem + ",\n"}
+}
+
+// addImportEdit adds importPath to the first import declaration of file, or
+// adds one after the package clause.
+func (p *parsedPackage) addImportEdit(file *ast.File, importPath string) textEdit {
+	spec := strconv.Quote(importPath)
+
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+
+		if gen.Lparen.IsValid() {
+			return textEdit{offset: p.fset.Position(gen.Lparen).Offset + 1, text: "\n" + spec + "\n"}
+		}
+
+		return textEdit{offset: p.fset.Position(gen.End()).Offset, text: "\nimport " + spec + "\n"}
+	}
+
+	return textEdit{offset: p.fset.Position(file.Name.End()).Offset, text: "\n\nimport " + spec + "\n"}
+}
+
+// removeElementEdit removes the element at index from lit together with its
+// separating comma, and its whole line when it sits on a line of its own.
+func (p *parsedPackage) removeElementEdit(lit *ast.CompositeLit, index int, src []byte) textEdit {
+	start := p.fset.Position(lit.Elts[index].Pos()).Offset
+	end := p.fset.Position(lit.Elts[index].End()).Offset
+
+	next := skipBlanks(src, end)
+	if next < len(src) && src[next] == ',' {
+		end = skipBlanks(src, next+1)
+		if end < len(src) && src[end] == '\n' {
+			end++
+
+			if lineStart := skipBlanksBackwards(src, start); lineStart == 0 || src[lineStart-1] == '\n' {
+				start = lineStart
+			}
+		}
+	} else if index > 0 {
+		start = p.fset.Position(lit.Elts[index-1].End()).Offset
+	}
+
+	return textEdit{offset: start, end: end}
+}
+
+// removeImportEdit removes spec, or its whole declaration when not parenthesised.
+func (p *parsedPackage) removeImportEdit(file *ast.File, spec *ast.ImportSpec, src []byte) textEdit {
+	start, end := spec.Pos(), spec.End()
+
+	for _, decl := range file.Decls {
+		if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.IMPORT && !gen.Lparen.IsValid() && len(gen.Specs) == 1 && gen.Specs[0] == spec {
+			start, end = gen.Pos(), gen.End()
+		}
+	}
+
+	startOffset := skipBlanksBackwards(src, p.fset.Position(start).Offset)
+	endOffset := skipBlanks(src, p.fset.Position(end).Offset)
+
+	if endOffset < len(src) && src[endOffset] == '\n' {
+		endOffset++
+	}
+
+	return textEdit{offset: startOffset, end: endOffset}
+}
+
+func skipBlanks(src []byte, i int) int {
+	for i < len(src) && (src[i] == ' ' || src[i] == '\t') {
+		i++
+	}
+
+	return i
+}
+
+func skipBlanksBackwards(src []byte, i int) int {
+	for i > 0 && (src[i-1] == ' ' || src[i-1] == '\t') {
+		i--
+	}
+
+	return i
+}
+
+// applyTextEdits applies edits from the end of src backwards so that earlier offsets stay valid.
+func applyTextEdits(src []byte, edits []textEdit) []byte {
+	sort.Slice(edits, func(i, j int) bool {
+		return edits[i].offset > edits[j].offset
+	})
+
+	out := append([]byte(nil), src...)
+	for _, edit := range edits {
+		end := max(edit.end, edit.offset)
+		out = append(out[:edit.offset], append([]byte(edit.text), out[end:]...)...)
+	}
+
+	return out
+}
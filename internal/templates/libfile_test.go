@@ -0,0 +1,172 @@
+package templates
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanLibEdit(t *testing.T) {
+	action := &ActionTriggerMetadata{Kind: "action", Constructor: "actions.NewSendMessageAction"}
+	trigger := &ActionTriggerMetadata{Kind: "trigger", Constructor: "triggers.NewNewMessageTrigger"}
+
+	testCases := []struct {
+		name     string
+		files    map[string]string
+		meta     *ActionTriggerMetadata
+		file     string
+		contains []string
+		err      string
+	}{
+		{
+			name: "empty literal without import",
+			files: map[string]string{"lib.go": `package slack
+
+import "github.com/wakflo/go-sdk/sdk"
+
+type Lib struct{}
+
+// Actions lists the actions.
+func (l *Lib) Actions() []sdk.Action {
+	return []sdk.Action{}
+}
+`},
+			meta:     action,
+			file:     "lib.go",
+			contains: []string{`"example.com/integrations/slack/actions"`, "\t\tactions.NewSendMessageAction(),\n", "// Actions lists the actions."},
+		},
+		{
+			name: "literal through a variable in another file",
+			files: map[string]string{
+				"lib.go": `package slack
+
+type Lib struct{}
+`,
+				"registry.go": `package slack
+
+import (
+	"github.com/wakflo/go-sdk/sdk"
+
+	"example.com/integrations/slack/triggers"
+)
+
+func (l *Lib) Triggers() []sdk.Trigger {
+	list := []sdk.Trigger{triggers.NewOtherTrigger()}
+	return list
+}
+`,
+			},
+			meta:     trigger,
+			file:     "registry.go",
+			contains: []string{"triggers.NewOtherTrigger(),\n\t\ttriggers.NewNewMessageTrigger(),\n"},
+		},
+		{
+			name: "aliased import",
+			files: map[string]string{"lib.go": `package slack
+
+import (
+	"github.com/wakflo/go-sdk/sdk"
+	acts "example.com/integrations/slack/actions"
+)
+
+type Lib struct{}
+
+func (l *Lib) Actions() []sdk.Action {
+	return []sdk.Action{
+		acts.NewOtherAction(),
+	}
+}
+`},
+			meta:     action,
+			file:     "lib.go",
+			contains: []string{"acts.NewSendMessageAction(),"},
+		},
+		{
+			name: "already registered",
+			files: map[string]string{"lib.go": `package slack
+
+import (
+	"github.com/wakflo/go-sdk/sdk"
+	"example.com/integrations/slack/actions"
+)
+
+type Lib struct{}
+
+func (l *Lib) Actions() []sdk.Action {
+	return []sdk.Action{actions.NewSendMessageAction()}
+}
+`},
+			meta: action,
+			err:  "already registered",
+		},
+		{
+			name: "no method",
+			files: map[string]string{"lib.go": `package slack
+
+type Lib struct{}
+`},
+			meta: action,
+			err:  "could not find the Actions() method",
+		},
+		{
+			name: "built with append",
+			files: map[string]string{"lib.go": `package slack
+
+import "github.com/wakflo/go-sdk/sdk"
+
+type Lib struct{}
+
+func (l *Lib) Actions() []sdk.Action {
+	return append(common(), other())
+}
+`},
+			meta: action,
+			err:  "add it to Actions() manually",
+		},
+		{
+			name: "several return statements",
+			files: map[string]string{"lib.go": `package slack
+
+import "github.com/wakflo/go-sdk/sdk"
+
+type Lib struct{ beta bool }
+
+func (l *Lib) Actions() []sdk.Action {
+	if l.beta {
+		return []sdk.Action{}
+	}
+	return []sdk.Action{}
+}
+`},
+			meta: action,
+			err:  "exactly one return statement",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := NewMemProject("/integrations")
+			require.NoError(t, afero.WriteFile(p.FS, "/integrations/go.mod", []byte("module example.com/integrations\n\ngo 1.23\n"), 0o644))
+
+			for name, content := range tc.files {
+				require.NoError(t, afero.WriteFile(p.FS, filepath.Join("/integrations/slack", name), []byte(content), 0o644))
+			}
+
+			edit, err := planLibEdit(p, "slack", tc.meta)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, filepath.Join("slack", tc.file), edit.path)
+
+			for _, s := range tc.contains {
+				assert.Contains(t, string(edit.content), s)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package templates
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/manifoldco/promptui"
+)
+
+// Choices offered when a generated file already exists.
+const (
+	choiceOverwrite = "Overwrite"
+	choiceSkip      = "Skip"
+	choiceDiff      = "Show diff"
+)
+
+// promptConflict asks what to do with the existing file at path.
+var promptConflict = func(path string) (string, error) {
+	_, choice, err := (&promptui.Select{
+		Label: fmt.Sprintf("'%s' already exists", path),
+		Items: []string{choiceOverwrite, choiceSkip, choiceDiff},
+	}).Run()
+
+	return choice, err
+}
+
+// resolveConflicts checks the files changes would create against the existing
+// ones. Existing files are overwritten with Force, and asked about one by one
+// when Interactive; otherwise nothing is changed and an error lists them all.
+// Skipped files are dropped from the returned changes.
+func (p *Project) resolveConflicts(changes changeSet) (changeSet, error) {
+	var (
+		resolved changeSet
+		existing []string
+	)
+
+	for _, change := range changes {
+		if !change.create || p.Force || !p.exists(change.path) {
+			resolved = append(resolved, change)
+			continue
+		}
+
+		if !p.Interactive {
+			existing = append(existing, fmt.Sprintf("'%s'", change.path))
+			continue
+		}
+
+		overwrite, err := p.askOverwrite(change)
+		if err != nil {
+			return nil, err
+		}
+
+		if overwrite {
+			resolved = append(resolved, change)
+		}
+	}
+
+	if len(existing) > 0 {
+		return nil, fmt.Errorf("refusing to overwrite %s (use --force to overwrite): %w", strings.Join(existing, ", "), os.ErrExist)
+	}
+
+	return resolved, nil
+}
+
+// askOverwrite prompts until the user chooses to overwrite or skip change.
+func (p *Project) askOverwrite(change fileChange) (bool, error) {
+	for {
+		choice, err := promptConflict(change.path)
+		if err != nil {
+			return false, fmt.Errorf("failed to resolve conflict on '%s': %w", change.path, err)
+		}
+
+		switch choice {
+		case choiceOverwrite:
+			return true, nil
+		case choiceSkip:
+			return false, nil
+		case choiceDiff:
+			if err := p.diff(changeSet{change}); err != nil {
+				return false, err
+			}
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package templates
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleAddResourceExisting(t *testing.T) {
+	input := &AddResourceInput{Name: "Post Message", Description: "Posts"}
+	resourceFile := filepath.Join("actions", "post_message.go")
+
+	testCases := []struct {
+		name        string
+		force       bool
+		interactive bool
+		choices     []string
+		err         error
+		replaced    bool
+		diff        bool
+	}{
+		{
+			name: "refused by default",
+			err:  os.ErrExist,
+		},
+		{
+			name:     "forced",
+			force:    true,
+			replaced: true,
+		},
+		{
+			name:        "overwritten",
+			interactive: true,
+			choices:     []string{choiceOverwrite, choiceOverwrite},
+			replaced:    true,
+		},
+		{
+			name:        "skipped after a diff",
+			interactive: true,
+			choices:     []string{choiceDiff, choiceSkip, choiceSkip},
+			diff:        true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var out bytes.Buffer
+			p := newTestProject(t, testIntegrationFiles)
+			p.Out = &out
+			require.NoError(t, HandleAddResource(context.Background(), "action", input, NewOfflineGenerator(), p))
+
+			require.NoError(t, p.apply(changeSet{{path: resourceFile, content: []byte("package actions\n")}}))
+			lib := readProjectFile(t, p, "lib.go")
+			readme := readProjectFile(t, p, readmeFile)
+
+			prompt := promptConflict
+			t.Cleanup(func() { promptConflict = prompt })
+
+			choices := tc.choices
+			promptConflict = func(string) (string, error) {
+				require.NotEmpty(t, choices, "unexpected prompt")
+				choice := choices[0]
+				choices = choices[1:]
+
+				return choice, nil
+			}
+
+			p.Force, p.Interactive = tc.force, tc.interactive
+
+			err := HandleAddResource(context.Background(), "action", input, NewOfflineGenerator(), p)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				assert.ErrorContains(t, err, "--force")
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Empty(t, choices)
+			assert.Equal(t, tc.replaced, readProjectFile(t, p, resourceFile) != "package actions\n")
+			assert.Equal(t, tc.diff, bytes.Contains(out.Bytes(), []byte("--- a/actions/post_message.go\n")))
+
+			// the resource is registered once
+			assert.Equal(t, lib, readProjectFile(t, p, "lib.go"))
+			assert.Equal(t, readme, readProjectFile(t, p, readmeFile))
+		})
+	}
+}
+
+func TestCreateIntegrationFolderExisting(t *testing.T) {
+	p := NewMemProject("/integrations")
+	meta := &CreateIntegrationProps{}
+	meta.Name = "Slack"
+
+	require.NoError(t, CreateIntegrationFolder(meta, p))
+	require.ErrorIs(t, CreateIntegrationFolder(meta, p), os.ErrExist)
+
+	p.Force = true
+	require.NoError(t, CreateIntegrationFolder(meta, p))
+}
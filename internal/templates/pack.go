@@ -0,0 +1,480 @@
+package templates
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/BurntSushi/toml"
+)
+
+// PackManifest is the file describing a template pack.
+const PackManifest = "pack.toml"
+
+// packKinds are the kinds of resources a template pack generates files for.
+var packKinds = []string{"integration", "action", "trigger"}
+
+// Pack is a set of templates scaffolding commands render files from. Its
+// manifest lists, for each kind of resource, the files to generate and the
+// template each one is rendered from:
+//
+//	name = "acme"
+//
+//	[[action]]
+//	path = "actions/{{ .FileName }}.go"
+//	template = "action.go.tmpl"
+//
+//	[[action]]
+//	path = "actions/{{ .FileName }}_test.go"
+//	template = "action_test.go.tmpl"
+//
+// Paths are templates too, relative to the integration folder. Kinds missing
+// from the manifest are generated from the default templates.
+type Pack struct {
+	Name  string
+	fsys  fs.FS
+	files map[string][]packFile
+}
+
+type packManifest struct {
+	Name        string      `toml:"name"`
+	Integration []packEntry `toml:"integration"`
+	Action      []packEntry `toml:"action"`
+	Trigger     []packEntry `toml:"trigger"`
+}
+
+type packEntry struct {
+	Path     string `toml:"path"`
+	Template string `toml:"template"`
+}
+
+type packFile struct {
+	path    *template.Template
+	content *template.Template
+}
+
+// ReadPack reads the template pack whose manifest is at the root of fsys.
+func ReadPack(fsys fs.FS) (*Pack, error) {
+	data, err := fs.ReadFile(fsys, PackManifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", PackManifest, err)
+	}
+
+	var manifest packManifest
+
+	md, err := toml.Decode(string(data), &manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", PackManifest, err)
+	}
+
+	if undecoded := md.Undecoded(); len(undecoded) > 0 {
+		return nil, fmt.Errorf("unknown key '%s' in %s, expected files for one of: %s", undecoded[0], PackManifest, strings.Join(packKinds, ", "))
+	}
+
+	pack := &Pack{Name: manifest.Name, fsys: fsys, files: map[string][]packFile{}}
+	entries := map[string][]packEntry{
+		"integration": manifest.Integration,
+		"action":      manifest.Action,
+		"trigger":     manifest.Trigger,
+	}
+
+	for kind, kindEntries := range entries {
+		for _, entry := range kindEntries {
+			if entry.Path == "" || entry.Template == "" {
+				return nil, fmt.Errorf("every %s file in %s needs a path and a template", kind, PackManifest)
+			}
+
+			content, err := fs.ReadFile(fsys, entry.Template)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read template of '%s': %w", entry.Path, err)
+			}
+
+			file, err := parsePackFile(entry.Path, entry.Template, string(content))
+			if err != nil {
+				return nil, err
+			}
+
+			pack.files[kind] = append(pack.files[kind], file)
+		}
+	}
+
+	return pack, nil
+}
+
+func parsePackFile(path, name, content string) (packFile, error) {
+	pathTemplate, err := template.New(path).Funcs(funcMap).Parse(path)
+	if err != nil {
+		return packFile{}, fmt.Errorf("failed to parse path '%s': %w", path, err)
+	}
+
+	contentTemplate, err := template.New(name).Funcs(funcMap).Parse(content)
+	if err != nil {
+		return packFile{}, fmt.Errorf("failed to parse template '%s': %w", name, err)
+	}
+
+	return packFile{path: pathTemplate, content: contentTemplate}, nil
+}
+
+//go:embed defaults
+var defaultFiles embed.FS
+
+// DefaultPack returns the templates built into the CLI.
+var DefaultPack = sync.OnceValue(func() *Pack {
+	fsys, err := fs.Sub(defaultFiles, "defaults")
+	if err != nil {
+		panic(err)
+	}
+
+	pack, err := ReadPack(fsys)
+	if err != nil {
+		panic(err)
+	}
+
+	return pack
+})
+
+// render renders the files of kind with data, into paths relative to the
+// integration folder.
+func (pk *Pack) render(kind string, data any) (changeSet, error) {
+	var changes changeSet
+
+	for _, file := range pk.kindFiles(kind) {
+		name, err := file.renderPath(data)
+		if err != nil {
+			return nil, err
+		}
+
+		var content strings.Builder
+		if err := file.content.Execute(&content, data); err != nil {
+			return nil, fmt.Errorf("failed to render '%s': %w", name, err)
+		}
+
+		changes = append(changes, fileChange{path: name, content: []byte(content.String()), create: true})
+	}
+
+	return changes, nil
+}
+
+// paths renders the paths of the files of kind with data, as render does,
+// for the commands editing the files of existing resources.
+func (pk *Pack) paths(kind string, data any) ([]string, error) {
+	var paths []string
+
+	for _, file := range pk.kindFiles(kind) {
+		name, err := file.renderPath(data)
+		if err != nil {
+			return nil, err
+		}
+
+		paths = append(paths, name)
+	}
+
+	return paths, nil
+}
+
+// kindFiles returns the files of kind, the default ones when pk has none.
+func (pk *Pack) kindFiles(kind string) []packFile {
+	if files, ok := pk.files[kind]; ok {
+		return files
+	}
+
+	return DefaultPack().files[kind]
+}
+
+// renderPath renders the path of f with data, which must stay inside the
+// integration folder.
+func (f packFile) renderPath(data any) (string, error) {
+	var path strings.Builder
+
+	if err := f.path.Execute(&path, data); err != nil {
+		return "", fmt.Errorf("failed to render path '%s': %w", f.path.Name(), err)
+	}
+
+	name := filepath.FromSlash(path.String())
+	if !filepath.IsLocal(name) {
+		return "", fmt.Errorf("path '%s' of template '%s' is outside the integration folder", name, f.content.Name())
+	}
+
+	return name, nil
+}
+
+// PackFile is a file generated from a template pack.
+type PackFile struct {
+	Kind     string `json:"kind"`
+	Path     string `json:"path"`
+	Template string `json:"template"`
+	Pack     string `json:"pack"`
+
+	fsys fs.FS
+}
+
+// Source returns the content of the template of f.
+func (f PackFile) Source() ([]byte, error) {
+	return fs.ReadFile(f.fsys, f.Template)
+}
+
+// Files lists the files generated for each kind of resource, those of the
+// default templates included for the kinds the pack leaves out.
+func (pk *Pack) Files() []PackFile {
+	var files []PackFile
+
+	for _, kind := range packKinds {
+		source := pk
+		if _, ok := pk.files[kind]; !ok {
+			source = DefaultPack()
+		}
+
+		for _, file := range source.files[kind] {
+			files = append(files, PackFile{
+				Kind:     kind,
+				Path:     file.path.Name(),
+				Template: file.content.Name(),
+				Pack:     source.Name,
+				fsys:     source.fsys,
+			})
+		}
+	}
+
+	return files
+}
+
+// ExportPack copies the manifest and templates of pack into the root of p, so
+// they can be edited and used as a template pack.
+func ExportPack(pack *Pack, p *Project) ([]string, error) {
+	var changes changeSet
+
+	err := fs.WalkDir(pack.fsys, ".", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		content, err := fs.ReadFile(pack.fsys, path)
+		if err != nil {
+			return err
+		}
+
+		name := filepath.FromSlash(path)
+		changes = append(changes, fileChange{path: name, content: content, summary: "exported " + name, create: true})
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template pack '%s': %w", pack.Name, err)
+	}
+
+	if err := p.commit(changes); err != nil {
+		return nil, err
+	}
+
+	return changes.summaries(), nil
+}
+
+// LoadPack returns the template pack at source: a local directory, a git
+// repository given as "git+<url>[#<ref>]" with an https, ssh or scp-like
+// "git@" URL, or the https URL of a .tar.gz archive.
+// Remote packs are fetched once into cacheDir, so they should be pinned to a
+// tag or a versioned archive. An empty source selects DefaultPack.
+func LoadPack(ctx context.Context, source, cacheDir string) (*Pack, error) {
+	if source == "" {
+		return DefaultPack(), nil
+	}
+
+	if strings.HasPrefix(source, "http://") {
+		return nil, fmt.Errorf("failed to fetch template pack '%s': only https URLs are supported", source)
+	}
+
+	dir := source
+
+	if isRemotePack(source) {
+		fetched, err := fetchPack(ctx, source, cacheDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch template pack '%s': %w", source, err)
+		}
+
+		dir = fetched
+	}
+
+	pack, err := ReadPack(os.DirFS(packRoot(dir)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load template pack '%s': %w", source, err)
+	}
+
+	return pack, nil
+}
+
+func isRemotePack(source string) bool {
+	return strings.HasPrefix(source, "git+") || strings.HasPrefix(source, "https://")
+}
+
+// packRoot returns dir, or the only folder in it when the manifest is there,
+// as in archives of a repository.
+func packRoot(dir string) string {
+	if _, err := os.Stat(filepath.Join(dir, PackManifest)); err == nil {
+		return dir
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) != 1 || !entries[0].IsDir() {
+		return dir
+	}
+
+	return filepath.Join(dir, entries[0].Name())
+}
+
+// fetchPack downloads source into a folder of cacheDir named after it, unless
+// it is there already, and returns that folder.
+func fetchPack(ctx context.Context, source, cacheDir string) (string, error) {
+	sum := sha256.Sum256([]byte(source))
+	dir := filepath.Join(cacheDir, hex.EncodeToString(sum[:8]))
+
+	if _, err := os.Stat(dir); err == nil {
+		return dir, nil
+	}
+
+	if err := os.MkdirAll(cacheDir, 0o755); err != nil {
+		return "", err
+	}
+
+	tmp, err := os.MkdirTemp(cacheDir, ".fetch-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmp)
+
+	if repo, ok := strings.CutPrefix(source, "git+"); ok {
+		err = cloneRepository(ctx, repo, tmp)
+	} else {
+		err = downloadArchive(ctx, source, tmp)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.Rename(tmp, dir); err != nil {
+		return "", err
+	}
+
+	return dir, nil
+}
+
+// cloneRepository clones the ref after '#' in repo, or its default branch.
+// Neither the URL nor the ref can pass options to git, and only the https and
+// ssh transports are allowed.
+func cloneRepository(ctx context.Context, repo, dir string) error {
+	url, ref, _ := strings.Cut(repo, "#")
+
+	if !slices.ContainsFunc([]string{"https://", "ssh://", "git@"}, func(prefix string) bool { return strings.HasPrefix(url, prefix) }) {
+		return fmt.Errorf("unsupported repository URL '%s', expected an https://, ssh:// or git@ URL", url)
+	}
+
+	if strings.HasPrefix(ref, "-") {
+		return fmt.Errorf("invalid ref '%s'", ref)
+	}
+
+	args := []string{"clone", "--quiet", "--depth", "1"}
+	if ref != "" {
+		args = append(args, "--branch", ref)
+	}
+
+	out, err := exec.CommandContext(ctx, "git", append(args, "--", url, dir)...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git clone failed: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+
+	return os.RemoveAll(filepath.Join(dir, ".git"))
+}
+
+// archiveClient downloads template pack archives.
+var archiveClient = http.DefaultClient
+
+// downloadArchive extracts the .tar.gz archive at url into dir.
+func downloadArchive(ctx context.Context, url, dir string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := archiveClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	gz, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read archive: %w", err)
+	}
+	defer gz.Close()
+
+	archive := tar.NewReader(gz)
+
+	for {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to read archive: %w", err)
+		}
+
+		name := filepath.FromSlash(header.Name)
+		if !filepath.IsLocal(name) {
+			return fmt.Errorf("archive entry '%s' is outside the archive", header.Name)
+		}
+
+		// links and special files have no use in a template pack
+		if !slices.Contains([]byte{tar.TypeReg, tar.TypeDir}, header.Typeflag) {
+			continue
+		}
+
+		path := filepath.Join(dir, name)
+		if header.Typeflag == tar.TypeDir {
+			if err := os.MkdirAll(path, 0o755); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if err := extractFile(archive, path); err != nil {
+			return err
+		}
+	}
+}
+
+func extractFile(r io.Reader, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(file, r)
+
+	return err
+}
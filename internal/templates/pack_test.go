@@ -0,0 +1,266 @@
+package templates
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPack overrides the action templates and generates a test file next to
+// each action.
+var testPack = map[string]string{
+	PackManifest: `name = "acme"
+
+[[action]]
+path = "actions/{{ .FileName }}.go"
+template = "action.go.tmpl"
+
+[[action]]
+path = "actions/{{ .FileName }}.md"
+template = "doc.md.tmpl"
+
+[[action]]
+path = "actions/{{ .FileName }}_test.go"
+template = "action_test.go.tmpl"
+`,
+	"action.go.tmpl":      "package actions\n\n// {{ .Name }} by acme\n",
+	"doc.md.tmpl":         "# {{ .Name }}\n",
+	"action_test.go.tmpl": "package actions\n\nvar _ = New{{ toPascal .FileName }}Action\n",
+}
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+}
+
+func TestReadPack(t *testing.T) {
+	testCases := []struct {
+		name  string
+		files map[string]string
+		err   string
+	}{
+		{
+			name:  "valid",
+			files: testPack,
+		},
+		{
+			name:  "missing manifest",
+			files: map[string]string{"action.go.tmpl": ""},
+			err:   "failed to read pack.toml",
+		},
+		{
+			name:  "unknown kind",
+			files: map[string]string{PackManifest: "[[actions]]\npath = \"a.go\"\ntemplate = \"a.tmpl\"\n"},
+			err:   "unknown key 'actions'",
+		},
+		{
+			name:  "missing template",
+			files: map[string]string{PackManifest: "[[trigger]]\npath = \"a.go\"\ntemplate = \"a.tmpl\"\n"},
+			err:   "failed to read template of 'a.go'",
+		},
+		{
+			name:  "invalid template",
+			files: map[string]string{PackManifest: "[[trigger]]\npath = \"a.go\"\ntemplate = \"a.tmpl\"\n", "a.tmpl": "{{ .Name "},
+			err:   "failed to parse template 'a.tmpl'",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, tc.files)
+
+			pack, err := ReadPack(os.DirFS(dir))
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "acme", pack.Name)
+		})
+	}
+}
+
+func TestPackRenderOutsideFolder(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{PackManifest: "[[action]]\npath = \"../{{ .FileName }}.go\"\ntemplate = \"a.tmpl\"\n", "a.tmpl": ""})
+
+	pack, err := ReadPack(os.DirFS(dir))
+	require.NoError(t, err)
+
+	_, err = pack.render("action", &ActionTriggerMetadata{FileName: "send"})
+	require.ErrorContains(t, err, "outside the integration folder")
+}
+
+func TestHandleAddResourcePack(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, testPack)
+
+	pack, err := LoadPack(context.Background(), dir, t.TempDir())
+	require.NoError(t, err)
+
+	p := newTestProject(t, testIntegrationFiles)
+	p.Pack = pack
+
+	require.NoError(t, HandleAddResource(context.Background(), "action", &AddResourceInput{Name: "Post Message", Description: "Posts"}, NewOfflineGenerator(), p))
+	assert.Equal(t, "package actions\n\n// Post Message by acme\n", readProjectFile(t, p, filepath.Join("actions", "post_message.go")))
+	assert.True(t, p.exists(filepath.Join("actions", "post_message_test.go")))
+	assert.Contains(t, readProjectFile(t, p, filepath.Join("actions", docFile)), "//go:embed post_message.md")
+
+	// triggers fall back to the default templates
+	changes, err := pack.render("trigger", &ActionTriggerMetadata{Name: "New Message", FileName: "new_message", TypeName: "sdkcore.TriggerTypePolling"})
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	assert.Equal(t, filepath.Join("triggers", "new_message.go"), changes[0].path)
+	assert.Contains(t, string(changes[0].content), "type NewMessageTrigger struct{}")
+}
+
+func TestPackFilesRenamedAndRemoved(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, testPack)
+
+	pack, err := ReadPack(os.DirFS(dir))
+	require.NoError(t, err)
+
+	p := newTestProject(t, testIntegrationFiles)
+	p.Pack = pack
+
+	require.NoError(t, HandleAddResource(context.Background(), "action", &AddResourceInput{Name: "Post Message", Description: "Posts"}, NewOfflineGenerator(), p))
+
+	changes, err := HandleRenameResource("action", "post_message", "Send Chat", p)
+	require.NoError(t, err)
+	assert.Contains(t, changes, "renamed actions/post_message_test.go to actions/send_chat_test.go")
+	assert.False(t, p.exists(filepath.Join("actions", "post_message_test.go")))
+	assert.Equal(t, "package actions\n\nvar _ = NewSendChatAction\n", readProjectFile(t, p, filepath.Join("actions", "send_chat_test.go")))
+
+	changes, err = HandleRemoveResource("action", "send_chat", p)
+	require.NoError(t, err)
+	assert.Contains(t, changes, "deleted actions/send_chat_test.go")
+	assert.False(t, p.exists(filepath.Join("actions", "send_chat_test.go")))
+}
+
+func TestLoadPackArchive(t *testing.T) {
+	var archive bytes.Buffer
+
+	gz := gzip.NewWriter(&archive)
+	tw := tar.NewWriter(gz)
+
+	for name, content := range testPack {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: "acme-templates-1.0.0/" + name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(archive.Bytes())
+	}))
+
+	client := archiveClient
+	archiveClient = server.Client()
+	t.Cleanup(func() { archiveClient = client })
+
+	source := server.URL + "/acme-templates-1.0.0.tar.gz"
+	cacheDir := t.TempDir()
+
+	pack, err := LoadPack(context.Background(), source, cacheDir)
+	require.NoError(t, err)
+	assert.Equal(t, "acme", pack.Name)
+
+	// the archive is not downloaded again
+	server.Close()
+
+	pack, err = LoadPack(context.Background(), source, cacheDir)
+	require.NoError(t, err)
+	assert.Equal(t, "acme", pack.Name)
+
+	_, err = LoadPack(context.Background(), "http"+strings.TrimPrefix(source, "https"), t.TempDir())
+	require.ErrorContains(t, err, "only https URLs are supported")
+}
+
+func TestLoadPackGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	repo := t.TempDir()
+	writeFiles(t, repo, testPack)
+
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"add", "."},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--quiet", "-m", "templates"},
+		{"tag", "v1.0.0"},
+	} {
+		git := exec.Command("git", args...)
+		git.Dir = repo
+
+		out, err := git.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	// only https and ssh URLs are cloned, point one at the local repository
+	const url = "https://git.example.test/acme/templates.git"
+
+	t.Setenv("GIT_CONFIG_COUNT", "1")
+	t.Setenv("GIT_CONFIG_KEY_0", "url.file://"+filepath.ToSlash(repo)+".insteadOf")
+	t.Setenv("GIT_CONFIG_VALUE_0", url)
+
+	pack, err := LoadPack(context.Background(), "git+"+url+"#v1.0.0", t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, "acme", pack.Name)
+
+	_, err = LoadPack(context.Background(), "git+"+url+"#v2.0.0", t.TempDir())
+	require.ErrorContains(t, err, "git clone failed")
+
+	_, err = LoadPack(context.Background(), "git+"+url+"#--upload-pack=touch", t.TempDir())
+	require.ErrorContains(t, err, "invalid ref")
+
+	_, err = LoadPack(context.Background(), "git+file://"+filepath.ToSlash(repo), t.TempDir())
+	require.ErrorContains(t, err, "unsupported repository URL")
+
+	_, err = LoadPack(context.Background(), "git+--upload-pack=touch", t.TempDir())
+	require.ErrorContains(t, err, "unsupported repository URL")
+}
+
+func TestExportPack(t *testing.T) {
+	p := NewMemProject("/templates")
+
+	exported, err := ExportPack(DefaultPack(), p)
+	require.NoError(t, err)
+	assert.Contains(t, exported, "exported pack.toml")
+	assert.Contains(t, exported, "exported action.go.tmpl")
+
+	// the exported copy is a template pack generating the same files
+	pack, err := ReadPack(afero.NewIOFS(afero.NewBasePathFs(p.FS, "/templates")))
+	require.NoError(t, err)
+
+	paths := func(files []PackFile) []string {
+		return lo.Map(files, func(file PackFile, _ int) string { return file.Kind + ":" + file.Path + ":" + file.Template })
+	}
+	assert.Equal(t, paths(DefaultPack().Files()), paths(pack.Files()))
+
+	_, err = ExportPack(DefaultPack(), p)
+	require.ErrorIs(t, err, os.ErrExist)
+}
@@ -0,0 +1,149 @@
+package templates
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+)
+
+// Project is the directory scaffolding commands work in: an integration
+// project, or the folder a new integration is created in. Commands render every
+// file they create or modify in memory first, then hand them to the project,
+// which writes them to its filesystem or, with DryRun set, prints them to Out
+// as a unified diff without touching it.
+//
+// FS defaults to the OS filesystem, Root to the working directory and Out to
+// the standard output. Paths used with a project are relative to Root.
+//
+// Generated files are rendered from Pack, DefaultPack when nil. They never
+// silently replace existing ones: Force overwrites them, Interactive asks
+// whether to overwrite each of them, skip it or show a diff.
+type Project struct {
+	FS          afero.Fs
+	Root        string
+	Pack        *Pack
+	DryRun      bool
+	Force       bool
+	Interactive bool
+	Out         io.Writer
+}
+
+// NewProject returns a project rooted at root on the OS filesystem.
+func NewProject(root string) *Project {
+	return &Project{FS: afero.NewOsFs(), Root: root}
+}
+
+// NewMemProject returns a project rooted at root on an empty in-memory filesystem.
+func NewMemProject(root string) *Project {
+	return &Project{FS: afero.NewMemMapFs(), Root: root}
+}
+
+func (p *Project) fs() afero.Fs {
+	if p.FS == nil {
+		return afero.NewOsFs()
+	}
+
+	return p.FS
+}
+
+func (p *Project) pack() *Pack {
+	if p.Pack == nil {
+		return DefaultPack()
+	}
+
+	return p.Pack
+}
+
+func (p *Project) out() io.Writer {
+	if p.Out == nil {
+		return os.Stdout
+	}
+
+	return p.Out
+}
+
+// path resolves name against the project root.
+func (p *Project) path(name string) string {
+	if filepath.IsAbs(name) || p.Root == "" {
+		return filepath.Clean(name)
+	}
+
+	return filepath.Join(p.Root, name)
+}
+
+func (p *Project) readFile(name string) ([]byte, error) {
+	return afero.ReadFile(p.fs(), p.path(name))
+}
+
+func (p *Project) stat(name string) (fs.FileInfo, error) {
+	return p.fs().Stat(p.path(name))
+}
+
+func (p *Project) exists(name string) bool {
+	_, err := p.stat(name)
+	return err == nil
+}
+
+// glob returns the names matching pattern, relative to the project root.
+func (p *Project) glob(pattern string) ([]string, error) {
+	matches, err := afero.Glob(p.fs(), p.path(pattern))
+	if err != nil {
+		return nil, err
+	}
+
+	for i, match := range matches {
+		if rel, err := filepath.Rel(p.path("."), match); err == nil && !filepath.IsAbs(pattern) {
+			matches[i] = rel
+		}
+	}
+
+	return matches, nil
+}
+
+// commit writes changes, or previews them in dry-run mode.
+func (p *Project) commit(changes changeSet) error {
+	changes, err := p.resolveConflicts(changes)
+	if err != nil {
+		return err
+	}
+
+	if p.DryRun {
+		if err := p.diff(changes); err != nil {
+			return err
+		}
+
+		fmt.Fprintln(p.out(), "Dry run: no file was changed.")
+
+		return nil
+	}
+
+	return p.apply(changes)
+}
+
+// printf reports progress of a command, unless the project is in dry-run mode.
+func (p *Project) printf(format string, args ...any) {
+	if p.DryRun {
+		return
+	}
+
+	fmt.Fprintf(p.out(), format, args...)
+}
+
+// readOptionalFile returns the content of name, or nil when it does not exist.
+func (p *Project) readOptionalFile(name string) ([]byte, error) {
+	data, err := p.readFile(name)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read '%s': %w", name, err)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,87 @@
+package templates
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectPaths(t *testing.T) {
+	p := NewMemProject("/integrations/slack")
+	for _, name := range []string{"actions/send_message.go", "actions/doc.go", "triggers/new_message.go"} {
+		require.NoError(t, afero.WriteFile(p.FS, p.path(name), []byte("package x\n"), 0o644))
+	}
+
+	assert.Equal(t, filepath.FromSlash("/integrations/slack/lib.go"), p.path("lib.go"))
+	assert.Equal(t, filepath.FromSlash("/integrations/gmail"), p.path("../gmail"))
+	assert.Equal(t, filepath.FromSlash("/tmp/lib.go"), p.path("/tmp/lib.go"))
+
+	matches, err := p.glob(filepath.Join("actions", "*.go"))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{filepath.Join("actions", "send_message.go"), filepath.Join("actions", "doc.go")}, matches)
+
+	assert.True(t, p.exists("triggers"))
+	assert.False(t, p.exists("flows"))
+
+	content, err := p.readOptionalFile("README.md")
+	require.NoError(t, err)
+	assert.Nil(t, content)
+}
+
+func TestProjectCommitRenderedTemplate(t *testing.T) {
+	p := NewMemProject("/integrations/slack")
+
+	content, err := renderTemplate("greeting", "Hello, {{.Name}}!", map[string]string{"Name": "World"})
+	require.NoError(t, err)
+	require.NoError(t, p.commit(changeSet{{path: "testfile.txt", content: content}}))
+
+	data, err := p.readFile("testfile.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, World!", string(data))
+}
+
+func TestReadIntegrationFile(t *testing.T) {
+	testCases := []struct {
+		name     string
+		files    map[string]string
+		expected string
+		err      string
+	}{
+		{
+			name:     "integration project",
+			files:    map[string]string{integrationFile: "[integration]\nname = \"Slack\"\n"},
+			expected: "Slack",
+		},
+		{
+			name:  "missing flo.toml",
+			files: map[string]string{"integration.toml": "[integration]\nname = \"Slack\"\n"},
+			err:   "not an integration project: missing 'flo.toml' file",
+		},
+		{
+			name:  "invalid flo.toml",
+			files: map[string]string{integrationFile: "[integration\n"},
+			err:   "failed to parse 'flo.toml' file",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := NewMemProject("/integrations/slack")
+			for name, content := range tc.files {
+				require.NoError(t, afero.WriteFile(p.FS, p.path(name), []byte(content), 0o644))
+			}
+
+			model, err := readIntegrationFile(p, integrationFile)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, model.Integration.Name)
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package templates
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+const docFile = "doc.go"
+
+// HandleRemoveResource deletes an action or trigger of the current integration
+// project and reverts every edit HandleAddResource made for it: the files the
+// template pack generated, the doc.go embed, the lib.go registration and the
+// README row. Either all of them are changed or none is. It returns a summary
+// of the changes.
+func HandleRemoveResource(kind, name string, p *Project) ([]string, error) {
+	if _, err := readIntegrationFile(p, integrationFile); err != nil {
+		return nil, err
+	}
+
+	fileName := formatFileName(name)
+	meta := &ActionTriggerMetadata{
+		Name:        name,
+		FileName:    fileName,
+		Kind:        kind,
+		Constructor: getConstructorName(kind, name),
+	}
+
+	resourceFolder := kind + "s"
+	resourceFile := filepath.Join(resourceFolder, fileName+".go")
+
+	if _, err := p.stat(resourceFile); errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%s '%s' does not exist: missing '%s'", kind, name, resourceFile)
+	}
+
+	changes := changeSet{{path: resourceFile, summary: "deleted " + resourceFile}}
+
+	docFileName := filepath.Join(resourceFolder, fileName+".md")
+	if p.exists(docFileName) {
+		changes = append(changes, fileChange{path: docFileName, summary: "deleted " + docFileName})
+	}
+
+	// and the other files the template pack generated for it
+	packPaths, err := p.pack().paths(kind, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, path := range packPaths {
+		if path != resourceFile && path != docFileName && p.exists(path) {
+			changes = append(changes, fileChange{path: path, summary: "deleted " + path})
+		}
+	}
+
+	docChange, err := docFileChange(p, resourceFolder, kind, func(mdFiles []string) []string {
+		return lo.Without(mdFiles, docFileName)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	changes = append(changes, docChange)
+
+	edit, err := planLibRemoval(p, ".", meta)
+	switch {
+	case errors.Is(err, errNotRegistered):
+	case err != nil:
+		return nil, err
+	default:
+		changes = append(changes, fileChange{path: edit.path, content: edit.content, summary: fmt.Sprintf("removed %s() from %s", meta.Constructor, filepath.Base(edit.path))})
+	}
+
+	readme, err := p.readOptionalFile(readmeFile)
+	if err != nil {
+		return nil, err
+	}
+
+	if updated, removed := removeReadmeRow(string(readme), kind, fileName); removed {
+		changes = append(changes, fileChange{path: readmeFile, content: []byte(updated), summary: fmt.Sprintf("removed %s row from %s", fileName, readmeFile)})
+	}
+
+	if err := p.commit(changes); err != nil {
+		return nil, fmt.Errorf("failed to remove %s, no file was changed: %w", kind, err)
+	}
+
+	return changes.summaries(), nil
+}
+
+// removeReadmeRow drops the table row linking to the documentation of the resource.
+func removeReadmeRow(content, kind, fileName string) (string, bool) {
+	link := fmt.Sprintf("(%s/%s.md)", kind+"s", fileName)
+
+	lines := strings.SplitAfter(content, "\n")
+	kept := lines[:0]
+
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "|") && strings.Contains(line, link) {
+			continue
+		}
+
+		kept = append(kept, line)
+	}
+
+	if len(kept) == len(lines) {
+		return content, false
+	}
+
+	return strings.TrimSuffix(strings.Join(kept, ""), "\n") + "\n", true
+}
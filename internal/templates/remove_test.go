@@ -0,0 +1,72 @@
+package templates
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testLibFile = `package slack
+
+import (
+	"github.com/wakflo/go-sdk/sdk"
+)
+
+type Slack struct{}
+
+func (n *Slack) Actions() []sdk.Action {
+	return []sdk.Action{}
+}
+`
+
+// testIntegrationFiles completes the project of newTestProject into a Go module.
+var testIntegrationFiles = map[string]string{
+	"go.mod":   "module example.com/slack\n\ngo 1.23\n",
+	"lib.go":   testLibFile,
+	readmeFile: "# Slack\n",
+}
+
+func TestHandleRemoveResource(t *testing.T) {
+	p := newTestProject(t, testIntegrationFiles)
+
+	for _, name := range []string{"Post Message", "Archive Channel"} {
+		require.NoError(t, HandleAddResource(context.Background(), "action", &AddResourceInput{Name: name, Description: name}, NewOfflineGenerator(), p))
+	}
+
+	changes, err := HandleRemoveResource("action", "post_message", p)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"deleted actions/post_message.go",
+		"deleted actions/post_message.md",
+		"regenerated actions/doc.go",
+		"removed actions.NewPostMessageAction() from lib.go",
+		"removed post_message row from README.md",
+	}, changes)
+
+	assert.False(t, p.exists(filepath.Join("actions", "post_message.go")))
+	assert.False(t, p.exists(filepath.Join("actions", "post_message.md")))
+	assert.True(t, p.exists(filepath.Join("actions", "archive_channel.go")))
+
+	doc := readProjectFile(t, p, filepath.Join("actions", docFile))
+	assert.NotContains(t, doc, "post_message.md")
+	assert.Contains(t, doc, "archive_channel.md")
+
+	lib := readProjectFile(t, p, "lib.go")
+	assert.NotContains(t, lib, "NewPostMessageAction")
+	assert.Contains(t, lib, "actions.NewArchiveChannelAction(),")
+
+	readme := readProjectFile(t, p, readmeFile)
+	assert.NotContains(t, readme, "post_message.md")
+	assert.Contains(t, readme, "archive_channel.md")
+
+	// removing the last action also drops the now unused import
+	_, err = HandleRemoveResource("action", "Archive Channel", p)
+	require.NoError(t, err)
+	assert.NotContains(t, readProjectFile(t, p, "lib.go"), "example.com/slack/actions")
+
+	_, err = HandleRemoveResource("action", "archive_channel", p)
+	require.ErrorContains(t, err, "does not exist")
+}
@@ -0,0 +1,409 @@
+package templates
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// HandleRenameResource renames an action or trigger of the current integration
+// project: the files its template pack generated, the type, props, constructor
+// and docs identifiers generated from its file name, tests included, the name
+// it reports, its doc.go embed, its lib.go registration and its README row.
+// Either all of them are changed or none is. It returns a summary of the
+// changes.
+func HandleRenameResource(kind, oldName, newName string, p *Project) ([]string, error) {
+	if _, err := readIntegrationFile(p, integrationFile); err != nil {
+		return nil, err
+	}
+
+	if err := validateResourceName(kind, newName); err != nil {
+		return nil, err
+	}
+
+	oldMeta := resourceNames(kind, oldName)
+	newMeta := resourceNames(kind, newName)
+
+	if oldMeta.FileName == newMeta.FileName {
+		return nil, fmt.Errorf("%s '%s' is already called '%s'", kind, oldName, newMeta.FileName)
+	}
+
+	resourceFolder := kind + "s"
+	oldFile := filepath.Join(resourceFolder, oldMeta.FileName+".go")
+	newFile := filepath.Join(resourceFolder, newMeta.FileName+".go")
+
+	if _, err := p.stat(oldFile); errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%s '%s' does not exist: missing '%s'", kind, oldName, oldFile)
+	}
+
+	if p.exists(newFile) {
+		return nil, fmt.Errorf("cannot rename %s to '%s': '%s' already exists", kind, newName, newFile)
+	}
+
+	oldDoc := filepath.Join(resourceFolder, oldMeta.FileName+".md")
+	newDoc := filepath.Join(resourceFolder, newMeta.FileName+".md")
+
+	moves, err := packMoves(p, kind, oldMeta, newMeta, oldFile, oldDoc)
+	if err != nil {
+		return nil, err
+	}
+
+	// test files refer to the renamed identifiers too
+	pkg, err := parsePackage(p, resourceFolder, true)
+	if err != nil {
+		return nil, err
+	}
+
+	renames := identifierRenames(kind, oldMeta.FileName, newMeta.FileName)
+
+	docsVar, err := pkg.embedVariable(oldMeta.FileName + ".md")
+	if err != nil {
+		return nil, err
+	}
+
+	if docsVar != "" {
+		renames[docsVar] = templateName("toCamelCase", newMeta.FileName) + "Docs"
+	}
+
+	var (
+		changes     changeSet
+		displayName string
+	)
+
+	for _, file := range pkg.files {
+		// doc.go is regenerated below
+		if filepath.Base(file.path) == docFile {
+			continue
+		}
+
+		edits := pkg.renameEdits(file, renames)
+
+		if file.path == oldFile {
+			var edit *textEdit
+			displayName, edit = pkg.nameLiteral(file, renames, newName)
+
+			if edit != nil {
+				edits = append(edits, *edit)
+			}
+		}
+
+		newPath, moved := moves[file.path]
+
+		if len(edits) == 0 && file.path != oldFile && !moved {
+			continue
+		}
+
+		content, err := format.Source(applyTextEdits(file.src, edits))
+		if err != nil {
+			return nil, fmt.Errorf("failed to format '%s': %w", file.path, err)
+		}
+
+		if file.path == oldFile {
+			changes = append(changes,
+				fileChange{path: newFile, content: content, summary: fmt.Sprintf("renamed %s to %s", oldFile, newFile)},
+				fileChange{path: oldFile},
+			)
+
+			continue
+		}
+
+		if moved {
+			changes = append(changes,
+				fileChange{path: newPath, content: content, summary: fmt.Sprintf("renamed %s to %s", file.path, newPath)},
+				fileChange{path: file.path},
+			)
+
+			delete(moves, file.path)
+
+			continue
+		}
+
+		changes = append(changes, fileChange{path: file.path, content: content, summary: "updated " + file.path})
+	}
+
+	// pack files outside the package are moved as they are
+	for _, oldPath := range slices.Sorted(maps.Keys(moves)) {
+		content, err := p.readFile(oldPath)
+		if err != nil {
+			return nil, err
+		}
+
+		changes = append(changes,
+			fileChange{path: moves[oldPath], content: content, summary: fmt.Sprintf("renamed %s to %s", oldPath, moves[oldPath])},
+			fileChange{path: oldPath},
+		)
+	}
+
+	if doc, err := p.readFile(oldDoc); err == nil {
+		changes = append(changes,
+			fileChange{path: newDoc, content: []byte(renameHeading(string(doc), displayName, newName)), summary: fmt.Sprintf("renamed %s to %s", oldDoc, newDoc)},
+			fileChange{path: oldDoc},
+		)
+	}
+
+	docChange, err := docFileChange(p, resourceFolder, kind, func(mdFiles []string) []string {
+		return lo.Map(mdFiles, func(mdFile string, _ int) string {
+			return lo.Ternary(mdFile == oldDoc, newDoc, mdFile)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	changes = append(changes, docChange)
+
+	edit, err := planLibRename(p, ".", oldMeta, newMeta)
+	switch {
+	case errors.Is(err, errNotRegistered):
+	case err != nil:
+		return nil, err
+	default:
+		changes = append(changes, fileChange{path: edit.path, content: edit.content, summary: fmt.Sprintf("renamed %s() to %s() in %s", oldMeta.Constructor, newMeta.Constructor, filepath.Base(edit.path))})
+	}
+
+	readme, err := p.readOptionalFile(readmeFile)
+	if err != nil {
+		return nil, err
+	}
+
+	if updated, renamed := renameReadmeRow(string(readme), kind, oldMeta.FileName, newMeta.FileName, newName); renamed {
+		changes = append(changes, fileChange{path: readmeFile, content: []byte(updated), summary: "updated " + readmeFile})
+	}
+
+	if err := p.commit(changes); err != nil {
+		return nil, fmt.Errorf("failed to rename %s, no file was changed: %w", kind, err)
+	}
+
+	return changes.summaries(), nil
+}
+
+// packMoves maps the existing files the template pack generated for the
+// resource, other than its .go and .md files, to their paths once renamed.
+func packMoves(p *Project, kind string, oldMeta, newMeta *ActionTriggerMetadata, oldFile, oldDoc string) (map[string]string, error) {
+	oldPaths, err := p.pack().paths(kind, oldMeta)
+	if err != nil {
+		return nil, err
+	}
+
+	newPaths, err := p.pack().paths(kind, newMeta)
+	if err != nil {
+		return nil, err
+	}
+
+	moves := map[string]string{}
+
+	for i, path := range oldPaths {
+		if path == oldFile || path == oldDoc || !p.exists(path) {
+			continue
+		}
+
+		if p.exists(newPaths[i]) {
+			return nil, fmt.Errorf("cannot rename %s to '%s': '%s' already exists", kind, newMeta.Name, newPaths[i])
+		}
+
+		moves[path] = newPaths[i]
+	}
+
+	return moves, nil
+}
+
+// resourceNames derives the names HandleAddResource generates from name.
+func resourceNames(kind, name string) *ActionTriggerMetadata {
+	return &ActionTriggerMetadata{
+		Name:        name,
+		FileName:    formatFileName(name),
+		Kind:        kind,
+		Constructor: getConstructorName(kind, name),
+	}
+}
+
+func templateName(fn, value string) string {
+	return funcMap[fn].(func(string) string)(value)
+}
+
+// identifierRenames maps the identifiers the resource templates derive from
+// the old file name to the ones derived from the new file name.
+func identifierRenames(kind, oldFileName, newFileName string) map[string]string {
+	suffix := strings.Title(kind)
+	renames := map[string]string{}
+
+	for _, ident := range []struct{ fn, prefix, suffix string }{
+		{fn: "toPascal", suffix: suffix},
+		{fn: "toPascal", prefix: "New", suffix: suffix},
+		{fn: "toCamelCase", suffix: suffix},
+		{fn: "toCamelCase", suffix: suffix + "Props"},
+		{fn: "toCamelCase", suffix: "Docs"},
+	} {
+		renames[ident.prefix+templateName(ident.fn, oldFileName)+ident.suffix] = ident.prefix + templateName(ident.fn, newFileName) + ident.suffix
+	}
+
+	return renames
+}
+
+// renameEdits renames the identifiers of file found in renames, including
+// mentions of them in comments.
+func (p *parsedPackage) renameEdits(file *parsedFile, renames map[string]string) []textEdit {
+	var edits []textEdit
+
+	ast.Inspect(file.ast, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok {
+			if renamed, found := renames[ident.Name]; found {
+				offset := p.fset.Position(ident.Pos()).Offset
+				edits = append(edits, textEdit{offset: offset, end: offset + len(ident.Name), text: renamed})
+			}
+		}
+
+		return true
+	})
+
+	words := regexp.MustCompile(`\b\w+\b`)
+
+	for _, group := range file.ast.Comments {
+		for _, comment := range group.List {
+			text := words.ReplaceAllStringFunc(comment.Text, func(word string) string {
+				if renamed, found := renames[word]; found {
+					return renamed
+				}
+
+				return word
+			})
+
+			if text != comment.Text {
+				offset := p.fset.Position(comment.Pos()).Offset
+				edits = append(edits, textEdit{offset: offset, end: offset + len(comment.Text), text: text})
+			}
+		}
+	}
+
+	return edits
+}
+
+// nameLiteral finds the string returned by the Name() method of the renamed
+// type and returns it along with the edit replacing it with name.
+func (p *parsedPackage) nameLiteral(file *parsedFile, renames map[string]string, name string) (string, *textEdit) {
+	for _, decl := range file.ast.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv == nil || fn.Name.Name != "Name" || len(fn.Body.List) != 1 || !receiverIn(fn, renames) {
+			continue
+		}
+
+		ret, ok := fn.Body.List[0].(*ast.ReturnStmt)
+		if !ok || len(ret.Results) != 1 {
+			continue
+		}
+
+		lit, ok := ret.Results[0].(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			continue
+		}
+
+		current, _ := strconv.Unquote(lit.Value)
+		offset := p.fset.Position(lit.Pos()).Offset
+
+		return current, &textEdit{offset: offset, end: offset + len(lit.Value), text: strconv.Quote(name)}
+	}
+
+	return "", nil
+}
+
+func receiverIn(fn *ast.FuncDecl, names map[string]string) bool {
+	typ := fn.Recv.List[0].Type
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+
+	ident, ok := typ.(*ast.Ident)
+	if !ok {
+		return false
+	}
+
+	_, found := names[ident.Name]
+
+	return found
+}
+
+// embedVariable returns the name of the variable embedding mdName in the package, if any.
+func (p *parsedPackage) embedVariable(mdName string) (string, error) {
+	var names []string
+
+	for _, file := range p.files {
+		for _, decl := range file.ast.Decls {
+			if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.VAR && gen.Doc != nil && embeds(gen.Doc, mdName) {
+				names = append(names, gen.Specs[0].(*ast.ValueSpec).Names[0].Name)
+			}
+		}
+	}
+
+	if len(names) > 1 {
+		return "", fmt.Errorf("'%s' is embedded several times (%s)", mdName, strings.Join(names, ", "))
+	}
+
+	if len(names) == 0 {
+		return "", nil
+	}
+
+	return names[0], nil
+}
+
+func embeds(doc *ast.CommentGroup, mdName string) bool {
+	for _, comment := range doc.List {
+		if strings.TrimSpace(strings.TrimPrefix(comment.Text, "//go:embed")) == mdName && strings.HasPrefix(comment.Text, "//go:embed ") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// renameHeading replaces the "# <old name>" title of the documentation.
+func renameHeading(doc, oldName, newName string) string {
+	if oldName == "" {
+		return doc
+	}
+
+	lines := strings.SplitAfter(doc, "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "# "+oldName {
+			lines[i] = strings.Replace(line, "# "+oldName, "# "+newName, 1)
+			break
+		}
+	}
+
+	return strings.Join(lines, "")
+}
+
+// renameReadmeRow updates the name and link of the table row of the resource.
+func renameReadmeRow(content, kind, oldFileName, newFileName, newName string) (string, bool) {
+	oldLink := fmt.Sprintf("(%s/%s.md)", kind+"s", oldFileName)
+	newLink := fmt.Sprintf("(%s/%s.md)", kind+"s", newFileName)
+
+	lines := strings.SplitAfter(content, "\n")
+	renamed := false
+
+	for i, line := range lines {
+		if !strings.HasPrefix(strings.TrimSpace(line), "|") || !strings.Contains(line, oldLink) {
+			continue
+		}
+
+		cells := strings.Split(line, "|")
+		if len(cells) > 2 {
+			cells[1] = " " + newName + " "
+		}
+
+		lines[i] = strings.Replace(strings.Join(cells, "|"), oldLink, newLink, 1)
+		renamed = true
+	}
+
+	return strings.Join(lines, ""), renamed
+}
@@ -0,0 +1,45 @@
+package templates
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleRenameResource(t *testing.T) {
+	p := newTestProject(t, testIntegrationFiles)
+	require.NoError(t, HandleAddResource(context.Background(), "action", &AddResourceInput{Name: "Post Message", Description: "Posts a message"}, NewOfflineGenerator(), p))
+
+	changes, err := HandleRenameResource("action", "post_message", "Send Chat", p)
+	require.NoError(t, err)
+	assert.Contains(t, changes, "renamed actions/post_message.go to actions/send_chat.go")
+	assert.Contains(t, changes, "renamed actions.NewPostMessageAction() to actions.NewSendChatAction() in lib.go")
+
+	assert.False(t, p.exists(filepath.Join("actions", "post_message.go")))
+	assert.False(t, p.exists(filepath.Join("actions", "post_message.md")))
+
+	source := readProjectFile(t, p, filepath.Join("actions", "send_chat.go"))
+	assert.Contains(t, source, "type SendChatAction struct{}")
+	assert.Contains(t, source, "type sendChatActionProps struct")
+	assert.Contains(t, source, "func NewSendChatAction() sdk.Action")
+	assert.Contains(t, source, "&sendChatDocs")
+	assert.Contains(t, source, `return "Send Chat"`)
+	assert.NotContains(t, source, "PostMessage")
+
+	assert.Contains(t, readProjectFile(t, p, filepath.Join("actions", "send_chat.md")), "# Send Chat\n")
+	assert.Contains(t, readProjectFile(t, p, filepath.Join("actions", docFile)), "//go:embed send_chat.md\nvar sendChatDocs string")
+	assert.Contains(t, readProjectFile(t, p, "lib.go"), "actions.NewSendChatAction(),")
+	assert.Contains(t, readProjectFile(t, p, readmeFile), "| Send Chat | Posts a message | [docs](actions/send_chat.md) |")
+
+	_, err = HandleRenameResource("action", "post_message", "Other", p)
+	require.ErrorContains(t, err, "does not exist")
+
+	_, err = HandleRenameResource("action", "send_chat", "../send_chat", p)
+	require.ErrorContains(t, err, "invalid action name")
+
+	_, err = HandleRenameResource("action", "send_chat", "send_message", p)
+	require.ErrorContains(t, err, "already exists")
+}
@@ -1,7 +1,7 @@
 package templates
 
 import (
-	"os"
+	"bytes"
 	"strings"
 	"text/template"
 
@@ -19,29 +19,16 @@ var funcMap = template.FuncMap{
 	"toPackageName": ToPackageName,
 }
 
-func WriteTemplateToFile(filePath, tmpl string, meta any) error {
-	// Create file
-	file, err := os.Create(filePath)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
+// renderTemplate executes tmpl with meta, using name in error messages.
+func renderTemplate(name, tmpl string, meta any) ([]byte, error) {
+	var buf bytes.Buffer
 
-	// Parse and execute template
-	t := template.Must(template.New(filePath).Funcs(funcMap).Parse(tmpl))
-	if err := t.Execute(file, meta); err != nil {
-		return err
+	t := template.Must(template.New(name).Funcs(funcMap).Parse(tmpl))
+	if err := t.Execute(&buf, meta); err != nil {
+		return nil, err
 	}
 
-	return nil
-}
-
-// IsIntegrationProject Check whether the current directory is an integration project folder
-func IsIntegrationProject() bool {
-	// Example: Check if a specific configuration file exists
-	// Replace "integration.config" with the appropriate file or folder to check
-	_, err := os.Stat("integration.toml")
-	return err == nil
+	return buf.Bytes(), nil
 }
 
 func ToPackageName(value string) string {
@@ -0,0 +1,39 @@
+// Package testutil holds helpers shared by the tests of several packages.
+package testutil
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// GoModule returns a temporary folder with a go.mod requiring the go-sdk
+// sources this module builds with, for tests building generated projects. The
+// go tool is set up to complete the go.mod, and the test is skipped in short
+// mode or when the sources are unavailable.
+func GoModule(t *testing.T) string {
+	t.Helper()
+
+	if testing.Short() {
+		t.Skip("builds a generated project")
+	}
+
+	sdkDir, err := exec.Command("go", "list", "-m", "-f", "{{ .Dir }}", "github.com/wakflo/go-sdk").Output()
+	if err != nil || len(bytes.TrimSpace(sdkDir)) == 0 {
+		t.Skipf("go-sdk sources unavailable: %v", err)
+	}
+
+	t.Setenv("GOFLAGS", "-mod=mod")
+	t.Setenv("GOWORK", "off")
+
+	root := t.TempDir()
+	goMod := fmt.Sprintf("module example.com/integrations\n\ngo 1.23\n\nrequire github.com/wakflo/go-sdk v0.0.0\n\nreplace github.com/wakflo/go-sdk => %s\n", bytes.TrimSpace(sdkDir))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "go.mod"), []byte(goMod), 0o644))
+
+	return root
+}